@@ -44,10 +44,18 @@ type KubernetesClusterStatus struct {
 	LastSyncedTime metav1.Time            `json:"lastSyncedTime,omitempty"`
 }
 
+func (obj *KubernetesCluster) GetPhase() string {
+	return string(obj.Status.Phase)
+}
+
 func (obj *KubernetesCluster) SetPhase(s string) {
 	obj.Status.Phase = KubernetesClusterPhase(s)
 }
 
+func (obj *KubernetesCluster) GetConditions() []metav1.Condition {
+	return obj.Status.Conditions
+}
+
 func (obj *KubernetesCluster) AddCondition(v metav1.Condition) {
 	obj.Status.Conditions = append(obj.Status.Conditions, v)
 }
@@ -49,10 +49,18 @@ type KubernetesClusterConfigurationStatus struct {
 	LastSyncedTime metav1.Time                         `json:"lastSyncedTime,omitempty"`
 }
 
+func (obj *KubernetesClusterConfiguration) GetPhase() string {
+	return string(obj.Status.Phase)
+}
+
 func (obj *KubernetesClusterConfiguration) SetPhase(s string) {
 	obj.Status.Phase = KubernetesClusterConfigurationPhase(s)
 }
 
+func (obj *KubernetesClusterConfiguration) GetConditions() []metav1.Condition {
+	return obj.Status.Conditions
+}
+
 func (obj *KubernetesClusterConfiguration) AddCondition(condition metav1.Condition) {
 	obj.Status.Conditions = append(obj.Status.Conditions, condition)
 }
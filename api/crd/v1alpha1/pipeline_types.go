@@ -24,6 +24,23 @@ type PipelineClusterSpec struct {
 
 type PipelineSpec struct {
 	Cluster PipelineClusterSpec `json:"cluster,omitempty"`
+	// Operation is the operation the pipeline runs on the cluster. It is PipelineOperationCreate if empty.
+	// +kubebuilder:validation:Enum=Create;Delete
+	Operation PipelineOperation `json:"operation,omitempty"`
+}
+
+type PipelineOperation string
+
+const (
+	// PipelineOperationCreate creates the KubernetesCluster and its KubernetesClusterConfiguration.
+	PipelineOperationCreate PipelineOperation = "Create"
+	// PipelineOperationDelete deletes the KubernetesClusterConfiguration and the KubernetesCluster.
+	PipelineOperationDelete PipelineOperation = "Delete"
+)
+
+// IsDelete returns true if the pipeline deletes the cluster.
+func (obj *Pipeline) IsDelete() bool {
+	return obj.Spec.Operation == PipelineOperationDelete
 }
 
 type PipelinePhase string
@@ -55,10 +72,18 @@ type PipelineStatus struct {
 	LastSyncedTime metav1.Time        `json:"lastSyncedTime,omitempty"`
 }
 
+func (obj *Pipeline) GetPhase() string {
+	return string(obj.Status.Phase)
+}
+
 func (obj *Pipeline) SetPhase(s string) {
 	obj.Status.Phase = PipelinePhase(s)
 }
 
+func (obj *Pipeline) GetConditions() []metav1.Condition {
+	return obj.Status.Conditions
+}
+
 func (obj *Pipeline) AddCondition(v metav1.Condition) {
 	obj.Status.Conditions = append(obj.Status.Conditions, v)
 }
@@ -79,3 +104,11 @@ func init() {
 }
 
 var PipelineGVR = GroupVersion.WithResource("pipelines")
+
+// PipelineLabelRequestID is the label of the client-supplied request ID that created the pipeline.
+// It makes the creation idempotent: a request with the same ID returns the existing pipeline.
+const PipelineLabelRequestID = "nokamoto.github.com/request-id"
+
+// PipelineLabelCluster is the label of the name of the KubernetesCluster the pipeline targets.
+// It detects a conflict with a pipeline that has not created the KubernetesCluster yet.
+const PipelineLabelCluster = "nokamoto.github.com/cluster"
@@ -1,41 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log/slog"
-	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/nokamoto/kaas-operator-prototype/internal/infra/kubernetes"
-	"github.com/nokamoto/kaas-operator-prototype/internal/infra/namegen"
-	"github.com/nokamoto/kaas-operator-prototype/internal/service/cluster"
-	"github.com/nokamoto/kaas-operator-prototype/internal/service/longrunningoperation"
-	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiserver"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
+	"github.com/spf13/pflag"
 )
 
 func main() {
-	// Create services
-	client, err := kubernetes.New()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Set up tracing
+	shutdown, err := tracing.Setup(ctx, "apis")
 	if err != nil {
-		slog.Error("failed to create Kubernetes client", "error", err)
+		slog.Error("failed to set up tracing", "error", err)
 		os.Exit(1)
 	}
-	clusterService := cluster.New(client, &namegen.Namegen{})
-	longrunningoperationService := longrunningoperation.New(client)
-
-	// Create HTTP server
-	mux := http.NewServeMux()
-	path, handler := v1alpha1connect.NewClusterServiceHandler(clusterService)
-	mux.Handle(path, handler)
-	path, handler = v1alpha1connect.NewLongRunningOperationServiceHandler(longrunningoperationService)
-	mux.Handle(path, handler)
+	defer func() {
+		if err := shutdown(context.Background()); err != nil {
+			slog.Error("failed to shut down tracing", "error", err)
+		}
+	}()
 
 	addr := os.Getenv("ADDR")
 	if addr == "" {
 		addr = ":8080"
 	}
 
-	slog.Info("starting server", "address", addr)
-	if err := http.ListenAndServe(addr, mux); err != nil {
-		slog.Error("failed to start server", "error", err)
+	opts := apiserver.DefaultOptions()
+	opts.Addr = addr
+	opts.Metrics = true
+	fs := pflag.NewFlagSet("apis", pflag.ContinueOnError)
+	opts.BindFlags(fs)
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		slog.Error("failed to parse flags", "error", err)
+		os.Exit(1)
+	}
+
+	server, err := apiserver.New(opts)
+	if err != nil {
+		slog.Error("failed to create server", "error", err)
+		os.Exit(1)
+	}
+	if err := server.Start(ctx); err != nil {
+		slog.Error("server stopped", "error", err)
 	}
 }
@@ -1,16 +1,15 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/nokamoto/kaas-operator-prototype/internal/cli"
 )
 
 func main() {
-	cmd := cli.New()
-	if err := cmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	cmd, err := cli.New().ExecuteC()
+	if err != nil {
+		cli.WriteError(os.Stderr, cmd, err)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -16,12 +16,18 @@ func main() {
 		Version: "v0.0.0",
 	}, nil)
 
-	r := apiclient.New(func() string {
-		baseURL := os.Getenv("KAAAS_OPERATOR_PROTOTYPE_API_URL")
-		if baseURL == "" {
-			baseURL = apiclient.DefaultBaseURL
-		}
-		return baseURL
+	options := apiclient.DefaultOptions()
+	if url := os.Getenv("KAAAS_OPERATOR_PROTOTYPE_API_URL"); url != "" {
+		options.BaseURL = url
+	}
+	options, err := options.WithEnv(os.Getenv)
+	if err != nil {
+		slog.Error("invalid API client options", "error", err)
+		os.Exit(1)
+	}
+	options.UserAgent = apiclient.UserAgent("kaas-mcpserver")
+	r := apiclient.New(func() apiclient.Options {
+		return options
 	})
 
 	// Register the Cluster Management tool
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiserver"
+	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	"github.com/nokamoto/kaas-operator-prototype/internal/controller/kubernetescluster"
+	"github.com/nokamoto/kaas-operator-prototype/internal/controller/kubernetesclusterconfiguration"
+	"github.com/nokamoto/kaas-operator-prototype/internal/controller/pipeline"
+	"github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// controllers maps the names accepted by --controllers to their setup functions.
+var controllers = map[string]boilerplate.SetupFunc{
+	"pipeline":                       pipeline.Setup,
+	"kubernetescluster":              kubernetescluster.Setup,
+	"kubernetesclusterconfiguration": kubernetesclusterconfiguration.Setup,
+}
+
+func main() {
+	names := controllerNames()
+	apiOpts := apiserver.DefaultOptions()
+	opts := boilerplate.MustLoadOptions(os.Args[1:], func(fs *pflag.FlagSet) {
+		fs.StringSliceVar(&names, "controllers", names, "Controllers to run ("+strings.Join(controllerNames(), ", ")+")")
+		fs.StringVar(&apiOpts.Addr, "api-bind-address", "", "The address the embedded Connect API server binds to. The API server is disabled if empty")
+		apiOpts.BindFlags(fs)
+	})
+
+	var setups []boilerplate.SetupFunc
+	for _, name := range names {
+		setup, ok := controllers[name]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown controller: %s, must be one of %s\n", name, strings.Join(controllerNames(), ", "))
+			os.Exit(1)
+		}
+		setups = append(setups, setup)
+	}
+	if apiOpts.Addr != "" {
+		setups = append(setups, func(mgr ctrl.Manager, _ boilerplate.Options) error {
+			// The metrics are served by the manager.
+			server, err := apiserver.New(apiOpts)
+			if err != nil {
+				return err
+			}
+			return mgr.Add(server)
+		})
+	}
+	boilerplate.Run(opts, setups...)
+}
+
+func controllerNames() []string {
+	var names []string
+	for name := range controllers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
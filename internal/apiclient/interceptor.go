@@ -0,0 +1,65 @@
+package apiclient
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// retryInterval is the interval before the first retry, doubled for every following retry up to maxRetryInterval.
+var (
+	retryInterval    = 200 * time.Millisecond
+	maxRetryInterval = 2 * time.Second
+)
+
+// header returns an interceptor that sets the Authorization and User-Agent headers of every request.
+func header(token, userAgent string) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if token != "" {
+				req.Header().Set("Authorization", "Bearer "+token)
+			}
+			if userAgent != "" {
+				req.Header().Set("User-Agent", userAgent)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// deadline returns an interceptor that bounds every call by the timeout.
+func deadline(timeout time.Duration) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return next(ctx, req)
+		}
+	}
+}
+
+// retry returns an interceptor that retries the calls of the procedures without side effects
+// up to the number of retries while they fail with CodeUnavailable, such as when the server is restarting.
+func retry(retries int) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IdempotencyLevel == connect.IdempotencyUnknown {
+				return next(ctx, req)
+			}
+			interval := retryInterval
+			for attempt := 0; ; attempt++ {
+				res, err := next(ctx, req)
+				if err == nil || connect.CodeOf(err) != connect.CodeUnavailable || attempt == retries {
+					return res, err
+				}
+				select {
+				case <-ctx.Done():
+					return res, err
+				case <-time.After(interval):
+				}
+				interval = min(2*interval, maxRetryInterval)
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package apiclient
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultBaseURL is the default base URL for the API client.
+// The default value is set to "http://localhost:8080", which is commonly used for local development.
+const DefaultBaseURL = "http://localhost:8080"
+
+// Environment variables that override the Options of every client, whichever configuration they come from.
+const (
+	EnvURL                  = "KCLI_URL"
+	EnvToken                = "KCLI_TOKEN"
+	EnvCertificateAuthority = "KCLI_CERTIFICATE_AUTHORITY"
+	EnvClientCertificate    = "KCLI_CLIENT_CERTIFICATE"
+	EnvClientKey            = "KCLI_CLIENT_KEY"
+	EnvRequestTimeout       = "KCLI_REQUEST_TIMEOUT"
+	EnvRetries              = "KCLI_RETRIES"
+	EnvProtocol             = "KCLI_PROTOCOL"
+)
+
+// Protocol is the RPC protocol to call the API server with.
+type Protocol string
+
+const (
+	ProtocolConnect Protocol = "connect"
+	// ProtocolGRPC requires HTTP/2, which is used without TLS for http URLs.
+	ProtocolGRPC    Protocol = "grpc"
+	ProtocolGRPCWeb Protocol = "grpcweb"
+)
+
+// ParseProtocol returns the protocol of the name.
+func ParseProtocol(name string) (Protocol, error) {
+	switch p := Protocol(name); p {
+	case ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown protocol %q, must be one of connect, grpc, grpcweb", name)
+	}
+}
+
+// Options configures how a Runtime connects to the API server.
+type Options struct {
+	// BaseURL is the base URL of the API server. DefaultBaseURL is used if empty.
+	BaseURL string
+	// Token is sent as a bearer token in the Authorization header if set.
+	Token string
+	// CertificateAuthority is the path of the PEM encoded certificates to verify the server with
+	// instead of the system roots.
+	CertificateAuthority string
+	// ClientCertificate and ClientKey are the paths of the PEM encoded client certificate and key for mutual TLS.
+	ClientCertificate string
+	ClientKey         string
+	// Timeout is the deadline of each call including its retries. No deadline is set if 0.
+	Timeout time.Duration
+	// Retries is the number of times an idempotent call failing with CodeUnavailable is retried with backoff.
+	Retries int
+	// Protocol is ProtocolConnect if empty.
+	Protocol Protocol
+	// UserAgent is sent in the User-Agent header instead of the default of connect-go if set.
+	UserAgent string
+}
+
+// DefaultOptions returns the options to call a local API server, retrying idempotent calls a few times.
+func DefaultOptions() Options {
+	return Options{
+		BaseURL:  DefaultBaseURL,
+		Timeout:  30 * time.Second,
+		Retries:  3,
+		Protocol: ProtocolConnect,
+	}
+}
+
+// WithEnv returns a copy of the options overridden by the environment variables that are set.
+func (o Options) WithEnv(getenv func(string) string) (Options, error) {
+	for env, field := range map[string]*string{
+		EnvURL:                  &o.BaseURL,
+		EnvToken:                &o.Token,
+		EnvCertificateAuthority: &o.CertificateAuthority,
+		EnvClientCertificate:    &o.ClientCertificate,
+		EnvClientKey:            &o.ClientKey,
+	} {
+		if v := getenv(env); v != "" {
+			*field = v
+		}
+	}
+	if v := getenv(EnvRequestTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return o, fmt.Errorf("invalid %s: %w", EnvRequestTimeout, err)
+		}
+		o.Timeout = d
+	}
+	if v := getenv(EnvRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return o, fmt.Errorf("invalid %s: %q is not a non-negative integer", EnvRetries, v)
+		}
+		o.Retries = n
+	}
+	if v := getenv(EnvProtocol); v != "" {
+		p, err := ParseProtocol(v)
+		if err != nil {
+			return o, fmt.Errorf("invalid %s: %w", EnvProtocol, err)
+		}
+		o.Protocol = p
+	}
+	return o, nil
+}
+
+func (o Options) baseURL() string {
+	if o.BaseURL == "" {
+		return DefaultBaseURL
+	}
+	return o.BaseURL
+}
@@ -1,33 +1,68 @@
 package apiclient
 
 import (
-	"net/http"
+	"fmt"
+	"runtime"
+	"runtime/debug"
 
+	"connectrpc.com/connect"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
 )
 
-// DefaultBaseURL is the default base URL for the API client.
-// The default value is set to "http://localhost:8080", which is commonly used for local development.
-const DefaultBaseURL = "http://localhost:8080"
-
 // Runtime provides methods to create API clients for the kaas-operator-prototype.
-// It uses a lazy evaluation for the base URL to allow dynamic configuration.
+// It uses a lazy evaluation for the options to allow dynamic configuration.
 type Runtime struct {
-	lazyBaseURL func() string
+	lazyOptions func() Options
+}
+
+// New creates a new Runtime instance with a lazy evaluation function for the options.
+func New(lazyOptions func() Options) *Runtime {
+	return &Runtime{lazyOptions: lazyOptions}
 }
 
-// New creates a new Runtime instance with a lazy evaluation function for the base URL.
-func New(lazyBaseURL func() string) *Runtime {
-	return &Runtime{lazyBaseURL: lazyBaseURL}
+// UserAgent returns a User-Agent of the program such as "kcli/v0.1.0 (go1.24.5)".
+// The version is the version of the main module, which is "(devel)" unless built by go install.
+func UserAgent(program string) string {
+	version := "(devel)"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		version = info.Main.Version
+	}
+	return fmt.Sprintf("%s/%s (%s)", program, version, runtime.Version())
 }
 
-func (r *Runtime) httpClient() *http.Client {
-	return &http.Client{}
+func (r *Runtime) clientOptions(opts Options) []connect.ClientOption {
+	// The first interceptor is the outermost, so that the deadline bounds all the retries
+	interceptors := []connect.Interceptor{header(opts.Token, opts.UserAgent)}
+	if opts.Timeout > 0 {
+		interceptors = append(interceptors, deadline(opts.Timeout))
+	}
+	if opts.Retries > 0 {
+		interceptors = append(interceptors, retry(opts.Retries))
+	}
+	options := []connect.ClientOption{connect.WithInterceptors(interceptors...)}
+	switch opts.Protocol {
+	case ProtocolGRPC:
+		options = append(options, connect.WithGRPC())
+	case ProtocolGRPCWeb:
+		options = append(options, connect.WithGRPCWeb())
+	}
+	return options
 }
 
 func (r *Runtime) ClusterService() v1alpha1connect.ClusterServiceClient {
+	opts := r.lazyOptions()
 	return v1alpha1connect.NewClusterServiceClient(
-		r.httpClient(),
-		r.lazyBaseURL(),
+		opts.httpClient(),
+		opts.baseURL(),
+		r.clientOptions(opts)...,
+	)
+}
+
+func (r *Runtime) LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient {
+	opts := r.lazyOptions()
+	return v1alpha1connect.NewLongRunningOperationServiceClient(
+		opts.httpClient(),
+		opts.baseURL(),
+		r.clientOptions(opts)...,
 	)
 }
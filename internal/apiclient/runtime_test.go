@@ -0,0 +1,258 @@
+package apiclient
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+// fakeClusterService fails the first calls with the code, and records the headers of the last call.
+type fakeClusterService struct {
+	v1alpha1connect.UnimplementedClusterServiceHandler
+	failures int
+	code     connect.Code
+	delay    time.Duration
+	calls    int
+	header   http.Header
+}
+
+func (f *fakeClusterService) call(ctx context.Context, header http.Header) error {
+	f.calls++
+	f.header = header
+	if f.delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(f.delay):
+		}
+	}
+	if f.calls <= f.failures {
+		return connect.NewError(f.code, errors.New("injected"))
+	}
+	return nil
+}
+
+func (f *fakeClusterService) GetCluster(ctx context.Context, req *connect.Request[v1alpha1.GetClusterRequest]) (*connect.Response[v1alpha1.Cluster], error) {
+	if err := f.call(ctx, req.Header()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1alpha1.Cluster{Name: req.Msg.GetName()}), nil
+}
+
+func (f *fakeClusterService) CreateCluster(ctx context.Context, req *connect.Request[v1alpha1.CreateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
+	if err := f.call(ctx, req.Header()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1alpha1.LongRunningOperation{}), nil
+}
+
+// newServer starts an HTTP/1.1 and HTTP/2 server of the service, with TLS if tls is true.
+func newServer(t *testing.T, f *fakeClusterService, tls bool) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(v1alpha1connect.NewClusterServiceHandler(f))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetHTTP1(true)
+	srv.Config.Protocols.SetHTTP2(true)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	if tls {
+		srv.EnableHTTP2 = true
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getCluster(opts Options) error {
+	r := New(func() Options {
+		return opts
+	})
+	_, err := r.ClusterService().GetCluster(context.Background(), connect.NewRequest(&v1alpha1.GetClusterRequest{
+		Name: "projects/p1/clusters/c1",
+	}))
+	return err
+}
+
+func TestRuntime_header(t *testing.T) {
+	f := &fakeClusterService{}
+	srv := newServer(t, f, false)
+	err := getCluster(Options{
+		BaseURL:   srv.URL,
+		Token:     "secret",
+		UserAgent: "kcli/v1.0.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer secret")
+	}
+	if got := f.header.Get("User-Agent"); got != "kcli/v1.0.0" {
+		t.Errorf("User-Agent = %q, want %q", got, "kcli/v1.0.0")
+	}
+}
+
+func TestRuntime_retry(t *testing.T) {
+	retryInterval = time.Millisecond
+	t.Cleanup(func() {
+		retryInterval = 200 * time.Millisecond
+	})
+	tests := []struct {
+		name      string
+		service   *fakeClusterService
+		retries   int
+		create    bool
+		wantCalls int
+		wantCode  connect.Code
+	}{
+		{
+			name:      "retry an idempotent call until it succeeds",
+			service:   &fakeClusterService{failures: 2, code: connect.CodeUnavailable},
+			retries:   3,
+			wantCalls: 3,
+		},
+		{
+			name:      "give up after the retries",
+			service:   &fakeClusterService{failures: 5, code: connect.CodeUnavailable},
+			retries:   2,
+			wantCalls: 3,
+			wantCode:  connect.CodeUnavailable,
+		},
+		{
+			name:      "do not retry other codes",
+			service:   &fakeClusterService{failures: 1, code: connect.CodeInternal},
+			retries:   3,
+			wantCalls: 1,
+			wantCode:  connect.CodeInternal,
+		},
+		{
+			name:      "do not retry a call with side effects",
+			service:   &fakeClusterService{failures: 1, code: connect.CodeUnavailable},
+			retries:   3,
+			create:    true,
+			wantCalls: 1,
+			wantCode:  connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newServer(t, tt.service, false)
+			opts := Options{BaseURL: srv.URL, Retries: tt.retries}
+			var err error
+			if tt.create {
+				_, err = New(func() Options { return opts }).ClusterService().CreateCluster(
+					context.Background(), connect.NewRequest(&v1alpha1.CreateClusterRequest{Parent: "projects/p1"}))
+			} else {
+				err = getCluster(opts)
+			}
+			if tt.wantCode == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantCode != 0 && connect.CodeOf(err) != tt.wantCode {
+				t.Errorf("code = %v, want %v", connect.CodeOf(err), tt.wantCode)
+			}
+			if tt.service.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", tt.service.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRuntime_timeout(t *testing.T) {
+	srv := newServer(t, &fakeClusterService{delay: time.Second}, false)
+	err := getCluster(Options{BaseURL: srv.URL, Timeout: 50 * time.Millisecond})
+	if connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+		t.Errorf("code = %v, want %v", connect.CodeOf(err), connect.CodeDeadlineExceeded)
+	}
+}
+
+func TestRuntime_protocol(t *testing.T) {
+	for _, protocol := range []Protocol{ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb} {
+		t.Run(string(protocol), func(t *testing.T) {
+			f := &fakeClusterService{}
+			srv := newServer(t, f, false)
+			if err := getCluster(Options{BaseURL: srv.URL, Protocol: protocol}); err != nil {
+				t.Fatal(err)
+			}
+			want := map[Protocol]string{
+				ProtocolConnect: "application/proto",
+				ProtocolGRPC:    "application/grpc",
+				ProtocolGRPCWeb: "application/grpc-web",
+			}[protocol]
+			if got := f.header.Get("Content-Type"); !strings.HasPrefix(got, want) {
+				t.Errorf("Content-Type = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestRuntime_tls(t *testing.T) {
+	srv := newServer(t, &fakeClusterService{}, true)
+	ca := filepath.Join(t.TempDir(), "ca.pem")
+	b := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(ca, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := getCluster(Options{BaseURL: srv.URL, CertificateAuthority: ca}); err != nil {
+		t.Errorf("with the certificate authority: %v", err)
+	}
+	if err := getCluster(Options{BaseURL: srv.URL, CertificateAuthority: ca, Protocol: ProtocolGRPC}); err != nil {
+		t.Errorf("with the certificate authority over gRPC: %v", err)
+	}
+	if err := getCluster(Options{BaseURL: srv.URL}); err == nil {
+		t.Error("without the certificate authority: error = nil, want an error")
+	}
+	err := getCluster(Options{BaseURL: srv.URL, CertificateAuthority: filepath.Join(t.TempDir(), "missing.pem")})
+	if err == nil || !strings.Contains(err.Error(), "failed to read the certificate authority") {
+		t.Errorf("with a missing certificate authority: error = %v", err)
+	}
+}
+
+func TestOptions_WithEnv(t *testing.T) {
+	env := map[string]string{
+		EnvURL:            "https://kaas.example.com",
+		EnvRequestTimeout: "1m",
+		EnvRetries:        "0",
+		EnvProtocol:       "grpcweb",
+	}
+	got, err := DefaultOptions().WithEnv(func(key string) string {
+		return env[key]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Options{
+		BaseURL:  "https://kaas.example.com",
+		Timeout:  time.Minute,
+		Retries:  0,
+		Protocol: ProtocolGRPCWeb,
+	}
+	if got != want {
+		t.Errorf("WithEnv() = %+v, want %+v", got, want)
+	}
+
+	for _, invalid := range []map[string]string{
+		{EnvRequestTimeout: "soon"},
+		{EnvRetries: "many"},
+		{EnvProtocol: "http3"},
+	} {
+		if _, err := DefaultOptions().WithEnv(func(key string) string { return invalid[key] }); err == nil {
+			t.Errorf("WithEnv(%v) error = nil, want an error", invalid)
+		}
+	}
+}
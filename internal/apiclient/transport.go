@@ -0,0 +1,59 @@
+package apiclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// errorTransport fails every request with the error of the client configuration,
+// so that a misconfigured client fails the call rather than its creation.
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// httpClient returns the HTTP client for the TLS settings and the protocol of the options.
+func (o Options) httpClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		return &http.Client{Transport: errorTransport{err: err}}
+	}
+	transport.TLSClientConfig = tlsConfig
+	if o.Protocol == ProtocolGRPC {
+		// gRPC requires HTTP/2, negotiated with TLS for https or used without TLS for http
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP2(true)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return &http.Client{Transport: transport}
+}
+
+func (o Options) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.CertificateAuthority != "" {
+		pem, err := os.ReadFile(o.CertificateAuthority)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the certificate authority: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", o.CertificateAuthority)
+		}
+		config.RootCAs = pool
+	}
+	if o.ClientCertificate != "" || o.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertificate, o.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
@@ -0,0 +1,285 @@
+// Package apiserver serves the Connect API services over HTTP.
+package apiserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/otelconnect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/audit"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authz"
+	"github.com/nokamoto/kaas-operator-prototype/internal/infra/kubernetes"
+	"github.com/nokamoto/kaas-operator-prototype/internal/infra/namegen"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/quota"
+	"github.com/nokamoto/kaas-operator-prototype/internal/ratelimit"
+	auditservice "github.com/nokamoto/kaas-operator-prototype/internal/service/audit"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/cluster"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/longrunningoperation"
+	quotaservice "github.com/nokamoto/kaas-operator-prototype/internal/service/quota"
+	"github.com/nokamoto/kaas-operator-prototype/internal/validation"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// shutdownTimeout is the maximum duration to wait for in-flight requests on shutdown.
+const shutdownTimeout = 10 * time.Second
+
+// Options configures the API server.
+type Options struct {
+	// Addr is the address the server listens on.
+	Addr string
+	// Metrics serves the Prometheus metrics at /metrics if true.
+	// It should be false if the metrics are already served by a controller manager.
+	Metrics bool
+	// TLSCertFile and TLSKeyFile serve the API over TLS if set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ClientCAFile enables mTLS authentication with client certificates signed by the CAs.
+	ClientCAFile string
+	// JWKSFile enables JWT authentication with the keys of the JWKS file.
+	JWKSFile string
+	// JWTIssuer is the expected iss claim of the JWTs. It is not verified if empty.
+	JWTIssuer string
+	// JWTAudience is the expected aud claim of the JWTs. It is not verified if empty.
+	JWTAudience string
+	// StaticTokensFile enables static bearer token authentication for development.
+	StaticTokensFile string
+	// AuthzPolicyFile enables authorization with the role bindings of the policy file. Requires authentication.
+	AuthzPolicyFile string
+	// AuditLog is the sink of the audit events: a file path, "-" for stdout, or empty to keep them in memory only.
+	AuditLog string
+	// RateLimit is the number of requests per second allowed to each caller. The rate is not limited if 0.
+	RateLimit float64
+	// RateLimitBurst is the number of requests a caller can make at once above the rate limit.
+	RateLimitBurst int
+	// Quota is the limits applied to every project. A limit of 0 means unlimited.
+	Quota quota.Limits
+}
+
+// DefaultOptions returns the options with the default rate limit and quota.
+func DefaultOptions() Options {
+	return Options{
+		RateLimit:      10,
+		RateLimitBurst: 20,
+		Quota: quota.Limits{
+			MaxClusters:          20,
+			MaxPendingOperations: 5,
+		},
+	}
+}
+
+// BindFlags registers the TLS, authentication, rate limit and quota options as flags.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.TLSCertFile, "tls-cert-file", o.TLSCertFile, "Path to the TLS certificate of the API server")
+	fs.StringVar(&o.TLSKeyFile, "tls-key-file", o.TLSKeyFile, "Path to the TLS private key of the API server")
+	fs.StringVar(&o.ClientCAFile, "client-ca-file", o.ClientCAFile, "Path to the CA bundle to authenticate client certificates with. Requires TLS")
+	fs.StringVar(&o.JWKSFile, "jwks-file", o.JWKSFile, "Path to the JWKS file to authenticate bearer JWTs with")
+	fs.StringVar(&o.JWTIssuer, "jwt-issuer", o.JWTIssuer, "Expected issuer of the bearer JWTs")
+	fs.StringVar(&o.JWTAudience, "jwt-audience", o.JWTAudience, "Expected audience of the bearer JWTs")
+	fs.StringVar(&o.StaticTokensFile, "static-tokens-file", o.StaticTokensFile, "Path to a YAML file of static bearer tokens. For development only")
+	fs.StringVar(&o.AuthzPolicyFile, "authz-policy-file", o.AuthzPolicyFile, "Path to a YAML file of role bindings to authorize callers with")
+	fs.StringVar(&o.AuditLog, "audit-log", o.AuditLog, "Path to the file to write the audit events to as JSON lines. Use - for stdout, or leave empty to keep them in memory only")
+	fs.Float64Var(&o.RateLimit, "rate-limit", o.RateLimit, "Requests per second allowed to each caller. Use 0 to disable rate limiting")
+	fs.IntVar(&o.RateLimitBurst, "rate-limit-burst", o.RateLimitBurst, "Requests a caller can make at once above the rate limit")
+	fs.IntVar(&o.Quota.MaxClusters, "max-clusters-per-project", o.Quota.MaxClusters, "Maximum number of clusters in a project. Use 0 for unlimited")
+	fs.IntVar(&o.Quota.MaxPendingOperations, "max-pending-operations-per-project", o.Quota.MaxPendingOperations, "Maximum number of operations in progress in a project. Use 0 for unlimited")
+}
+
+// authenticators returns the authenticators enabled by the options.
+func (o *Options) authenticators() ([]authn.Authenticator, error) {
+	var res []authn.Authenticator
+	if o.ClientCAFile != "" {
+		if o.TLSCertFile == "" {
+			return nil, errors.New("client CA file requires TLS")
+		}
+		res = append(res, authn.ClientCertAuthenticator{})
+	}
+	if o.StaticTokensFile != "" {
+		a, err := authn.NewStaticTokenAuthenticator(o.StaticTokensFile)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	if o.JWKSFile != "" {
+		a, err := authn.NewJWTAuthenticator(o.JWKSFile, o.JWTIssuer, o.JWTAudience)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, a)
+	}
+	return res, nil
+}
+
+// tlsConfig returns the TLS config verifying the client certificates if ClientCAFile is set.
+func (o *Options) tlsConfig() (*tls.Config, error) {
+	if o.ClientCAFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(o.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates in client CA file %s", o.ClientCAFile)
+	}
+	return &tls.Config{
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  pool,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// Server serves the ClusterService, the LongRunningOperationService, the AuditService and the QuotaService.
+//
+// Server implements manager.Runnable so that it can be embedded in a controller manager.
+type Server struct {
+	opts      Options
+	handler   http.Handler
+	tlsConfig *tls.Config
+	auditLog  *audit.Log
+}
+
+// New creates a Server backed by the Kubernetes cluster of the current kubeconfig or in-cluster config.
+func New(opts Options) (*Server, error) {
+	client, err := kubernetes.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+	q := quota.New(client, opts.Quota)
+	clusterService := cluster.New(client, &namegen.Namegen{}, q)
+	longrunningoperationService := longrunningoperation.New(client)
+
+	otelInterceptor, err := otelconnect.NewInterceptor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing interceptor: %w", err)
+	}
+	validationInterceptor, err := validation.NewInterceptor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validation interceptor: %w", err)
+	}
+	authenticators, err := opts.authenticators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticators: %w", err)
+	}
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	auditLog, err := audit.NewLog(opts.AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	auditService := auditservice.New(auditLog)
+	quotaService := quotaservice.New(q)
+
+	chain := []connect.Interceptor{otelInterceptor, metrics.NewInterceptor()}
+	if len(authenticators) > 0 {
+		if opts.RateLimit > 0 {
+			// Failed authentications are limited per address, since authn rejects them before the rate limit per subject below.
+			chain = append(chain, ratelimit.NewUnauthenticatedInterceptor(ratelimit.New(opts.RateLimit, max(opts.RateLimitBurst, 1))))
+		}
+		chain = append(chain, authn.NewInterceptor(authenticators...))
+	} else {
+		slog.Warn("authentication is disabled")
+	}
+	if opts.RateLimit > 0 {
+		// The rate limit is placed after authn to limit each authenticated subject rather than each address.
+		chain = append(chain, ratelimit.NewInterceptor(ratelimit.New(opts.RateLimit, max(opts.RateLimitBurst, 1))))
+	}
+	// The audit interceptor is placed before authz to record the denied calls as well.
+	chain = append(chain, audit.NewInterceptor(auditLog))
+	if opts.AuthzPolicyFile != "" {
+		if len(authenticators) == 0 {
+			return nil, errors.Join(errors.New("authorization requires authentication"), auditLog.Close())
+		}
+		policy, err := authz.LoadPolicy(opts.AuthzPolicyFile)
+		if err != nil {
+			return nil, errors.Join(err, auditLog.Close())
+		}
+		chain = append(chain, authz.NewInterceptor(policy))
+	} else {
+		slog.Warn("authorization is disabled")
+	}
+	// Requests are validated after authz so that unauthorized callers learn nothing about the constraints.
+	chain = append(chain, validationInterceptor)
+	interceptors := connect.WithInterceptors(chain...)
+	mux := http.NewServeMux()
+	path, handler := v1alpha1connect.NewClusterServiceHandler(clusterService, interceptors)
+	mux.Handle(path, handler)
+	path, handler = v1alpha1connect.NewLongRunningOperationServiceHandler(longrunningoperationService, interceptors)
+	mux.Handle(path, handler)
+	path, handler = v1alpha1connect.NewAuditServiceHandler(auditService, interceptors)
+	mux.Handle(path, handler)
+	path, handler = v1alpha1connect.NewQuotaServiceHandler(quotaService, interceptors)
+	mux.Handle(path, handler)
+	if opts.Metrics {
+		mux.Handle("/metrics", promhttp.HandlerFor(ctrlmetrics.Registry, promhttp.HandlerOpts{}))
+	}
+	return &Server{opts: opts, handler: authn.Middleware(mux), tlsConfig: tlsConfig, auditLog: auditLog}, nil
+}
+
+// Handler returns the HTTP handler of the server.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Start serves the API until the context is canceled, then shuts down gracefully.
+func (s *Server) Start(ctx context.Context) error {
+	defer func() {
+		if err := s.auditLog.Close(); err != nil {
+			slog.Error("failed to close audit log", "error", err)
+		}
+	}()
+	srv := &http.Server{
+		Addr:      s.opts.Addr,
+		Handler:   s.handler,
+		TLSConfig: s.tlsConfig,
+	}
+	// Accept HTTP/2 without TLS as well so that gRPC clients can call the API in plaintext
+	srv.Protocols = new(http.Protocols)
+	srv.Protocols.SetHTTP1(true)
+	srv.Protocols.SetHTTP2(true)
+	srv.Protocols.SetUnencryptedHTTP2(true)
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("starting server", "address", s.opts.Addr, "tls", s.opts.TLSCertFile != "")
+		if s.opts.TLSCertFile != "" {
+			errCh <- srv.ListenAndServeTLS(s.opts.TLSCertFile, s.opts.TLSKeyFile)
+			return
+		}
+		errCh <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false so that every replica of a controller manager serves the API.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
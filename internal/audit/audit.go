@@ -0,0 +1,162 @@
+// Package audit records the mutating calls to the Connect API server.
+//
+// Events are written as JSON lines to a sink and the recent ones are kept in memory to be listed by the AuditService.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxRecentEvents is the maximum number of events kept in memory.
+const maxRecentEvents = 1000
+
+// Event is a mutating call to the API and its outcome.
+type Event struct {
+	Time      time.Time      `json:"time"`
+	Subject   string         `json:"subject,omitempty"`
+	Procedure string         `json:"procedure"`
+	Project   string         `json:"project,omitempty"`
+	Request   map[string]any `json:"request,omitempty"`
+	Operation string         `json:"operation,omitempty"`
+	Pipeline  string         `json:"pipeline,omitempty"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message,omitempty"`
+}
+
+// Log writes events to a sink and keeps the recent ones in memory.
+type Log struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	recent []Event
+}
+
+// NewLog creates a Log writing to the sink.
+// The sink is a file path, "-" for stdout, or empty to keep the events in memory only.
+// The recent events of an existing file are loaded so that they remain listable after a restart.
+func NewLog(sink string) (*Log, error) {
+	switch sink {
+	case "":
+		return &Log{w: io.Discard}, nil
+	case "-":
+		return &Log{w: os.Stdout}, nil
+	}
+	recent, truncated, err := load(sink)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(sink, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if truncated {
+		// Terminate the truncated line so that the next event starts on its own line
+		if _, err := f.Write([]byte{'\n'}); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to write audit log: %w", err)
+		}
+	}
+	return &Log{w: f, closer: f, recent: recent}, nil
+}
+
+// load reads the most recent events of the file, and reports whether its last line is not terminated.
+// Malformed lines, such as a line truncated by a crash during a write, are skipped and counted in a warning
+// so that a damaged audit log does not prevent the server from starting.
+func load(file string) ([]Event, bool, error) {
+	f, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+	var events []Event
+	var malformed int
+	var lastErr error
+	var last []byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	scanner.Split(scanLines)
+	for scanner.Scan() {
+		last = scanner.Bytes()
+		var e Event
+		if err := json.Unmarshal(bytes.TrimSuffix(last, []byte{'\n'}), &e); err != nil {
+			malformed++
+			lastErr = err
+			continue
+		}
+		events = appendRecent(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to read audit log %s: %w", file, err)
+	}
+	if malformed > 0 {
+		slog.Warn("skipped malformed lines of the audit log", "file", file, "count", malformed, "error", lastErr)
+	}
+	return events, len(last) > 0 && !bytes.HasSuffix(last, []byte{'\n'}), nil
+}
+
+// scanLines is bufio.ScanLines keeping the newline, which tells a truncated last line apart.
+func scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func appendRecent(events []Event, e Event) []Event {
+	events = append(events, e)
+	if len(events) > maxRecentEvents {
+		events = events[len(events)-maxRecentEvents:]
+	}
+	return events
+}
+
+// Record writes the event to the sink.
+func (l *Log) Record(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recent = appendRecent(l.recent, e)
+	if _, err := l.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit event: %w", err)
+	}
+	return nil
+}
+
+// List returns at most limit recent events of the project, newest first.
+func (l *Log) List(project string, limit int) []Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var res []Event
+	for i := len(l.recent) - 1; i >= 0 && len(res) < limit; i-- {
+		if l.recent[i].Project == project {
+			res = append(res, l.recent[i])
+		}
+	}
+	return res
+}
+
+// Close closes the sink if it is a file.
+func (l *Log) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+func TestNewInterceptor(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "audit.log")
+	log, err := NewLog(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := NewInterceptor(log)
+	ctx := authn.WithIdentity(context.Background(), &authn.Identity{Method: authn.MethodJWT, Subject: "alice"})
+
+	create := &request[apiv1alpha1.CreateClusterRequest]{
+		Request: connect.NewRequest(&apiv1alpha1.CreateClusterRequest{
+			Parent:  "projects/p1",
+			Cluster: &apiv1alpha1.Cluster{DisplayName: "demo"},
+		}),
+		procedure: v1alpha1connect.ClusterServiceCreateClusterProcedure,
+	}
+	_, err = interceptor(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&apiv1alpha1.LongRunningOperation{Name: "projects/p1/operations/cluster-create-1"}), nil
+	})(ctx, create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteErr := connect.NewError(connect.CodePermissionDenied, errors.New("denied"))
+	deleteReq := &request[apiv1alpha1.DeleteClusterRequest]{
+		Request:   connect.NewRequest(&apiv1alpha1.DeleteClusterRequest{Name: "projects/p1/clusters/c1"}),
+		procedure: v1alpha1connect.ClusterServiceDeleteClusterProcedure,
+	}
+	_, _ = interceptor(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, deleteErr
+	})(ctx, deleteReq)
+	get := &request[apiv1alpha1.GetOperationRequest]{
+		Request:   connect.NewRequest(&apiv1alpha1.GetOperationRequest{Name: "projects/p1/operations/o1"}),
+		procedure: v1alpha1connect.LongRunningOperationServiceGetOperationProcedure,
+	}
+	_, _ = interceptor(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&apiv1alpha1.LongRunningOperation{}), nil
+	})(ctx, get)
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Event{
+		{
+			Subject:   "jwt:alice",
+			Procedure: v1alpha1connect.ClusterServiceDeleteClusterProcedure,
+			Project:   "p1",
+			Request:   map[string]any{"name": "projects/p1/clusters/c1"},
+			Code:      "permission_denied",
+			Message:   deleteErr.Error(),
+		},
+		{
+			Subject:   "jwt:alice",
+			Procedure: v1alpha1connect.ClusterServiceCreateClusterProcedure,
+			Project:   "p1",
+			Request:   map[string]any{"parent": "projects/p1", "cluster": map[string]any{"displayName": "demo"}},
+			Operation: "projects/p1/operations/cluster-create-1",
+			Pipeline:  "cluster-create-1",
+			Code:      "ok",
+		},
+	}
+	ignoreTime := cmpopts.IgnoreFields(Event{}, "Time")
+	if diff := cmp.Diff(want, log.List("p1", 10), ignoreTime); diff != "" {
+		t.Errorf("List() mismatch (-want +got):\n%s", diff)
+	}
+
+	// The events are loaded from the file after a restart.
+	reopened, err := NewLog(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if diff := cmp.Diff(want[:1], reopened.List("p1", 1), ignoreTime); diff != "" {
+		t.Errorf("List() after reopen mismatch (-want +got):\n%s", diff)
+	}
+	if got := reopened.List("p2", 10); len(got) != 0 {
+		t.Errorf("List() of another project = %v, want empty", got)
+	}
+}
+
+func TestNewLog_malformed(t *testing.T) {
+	sink := filepath.Join(t.TempDir(), "audit.log")
+	// The second line is not an event and the last one was truncated by a crash during the write
+	data := `{"time":"2025-01-02T03:04:05Z","subject":"alice","procedure":"/p/Create","project":"p1","code":"ok"}
+not json
+{"time":"2025-01-02T03:04:06Z","subject":"bob","procedure":"/p/Delete","project":"p1","code":"ok"}
+{"time":"2025-01-02T03:04:07Z","subject":"carol","proc`
+	if err := os.WriteFile(sink, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	subjects := func(log *Log) []string {
+		var res []string
+		for _, e := range log.List("p1", 10) {
+			res = append(res, e.Subject)
+		}
+		return res
+	}
+	log, err := NewLog(sink)
+	if err != nil {
+		t.Fatalf("NewLog() error = %v, want the malformed lines to be skipped", err)
+	}
+	if diff := cmp.Diff([]string{"bob", "alice"}, subjects(log)); diff != "" {
+		t.Errorf("List() subjects mismatch (-want +got):\n%s", diff)
+	}
+
+	// An event recorded after the truncated line is not lost on the next restart
+	if err := log.Record(Event{Subject: "dave", Project: "p1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewLog(sink)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if diff := cmp.Diff([]string{"dave", "bob", "alice"}, subjects(reopened)); diff != "" {
+		t.Errorf("List() subjects after reopen mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	got := map[string]any{
+		"name":   "projects/p1/clusters/c1",
+		"token":  "secret-value",
+		"nested": map[string]any{"clientSecret": "s", "list": []any{map[string]any{"password": "p"}}},
+	}
+	redactValue(got)
+	want := map[string]any{
+		"name":   "projects/p1/clusters/c1",
+		"token":  redacted,
+		"nested": map[string]any{"clientSecret": redacted, "list": []any{map[string]any{"password": redacted}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("redactValue() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// request overrides the procedure of a request, which is only set by a Connect handler.
+type request[T any] struct {
+	*connect.Request[T]
+	procedure string
+}
+
+func (r *request[T]) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
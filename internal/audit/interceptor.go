@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// redacted replaces the values of the sensitive fields.
+const redacted = "[REDACTED]"
+
+// sensitiveFields are the JSON field names whose values are redacted from the request payload.
+var sensitiveFields = []string{"token", "password", "secret", "credential", "kubeconfig", "privateKey"}
+
+// mutatingMethods are the method name prefixes of the procedures that are audited.
+var mutatingMethods = []string{"Create", "Update", "Delete"}
+
+// NewInterceptor returns an interceptor that records every mutating call to the log.
+// It must be placed after the authn interceptor to record the caller.
+func NewInterceptor(log *Log) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient || !isMutating(req.Spec().Procedure) {
+				return next(ctx, req)
+			}
+			res, err := next(ctx, req)
+			e := Event{
+				Time:      time.Now(),
+				Procedure: req.Spec().Procedure,
+				Project:   domain.ProjectOf(req.Any()),
+				Request:   redact(req.Any()),
+				Code:      "ok",
+			}
+			if id, ok := authn.IdentityFrom(ctx); ok {
+				e.Subject = id.Key()
+			}
+			if err != nil {
+				e.Code = connect.CodeOf(err).String()
+				e.Message = err.Error()
+			} else if lro, ok := res.Any().(*apiv1alpha1.LongRunningOperation); ok {
+				e.Operation = lro.GetName()
+				if name, err := domain.ParseOperationName(lro.GetName()); err == nil {
+					e.Pipeline = name.Operation
+				}
+			}
+			if err := log.Record(e); err != nil {
+				slog.ErrorContext(ctx, "failed to record audit event", "error", err, "procedure", e.Procedure)
+			}
+			return res, err
+		}
+	}
+}
+
+func isMutating(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	for _, prefix := range mutatingMethods {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact returns the request payload as a JSON object with the values of the sensitive fields redacted.
+func redact(req any) map[string]any {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	redactValue(m)
+	return m
+}
+
+func redactValue(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if isSensitive(k) {
+				v[k] = redacted
+				continue
+			}
+			redactValue(child)
+		}
+	case []any:
+		for _, child := range v {
+			redactValue(child)
+		}
+	}
+}
+
+func isSensitive(field string) bool {
+	field = strings.ToLower(field)
+	for _, s := range sensitiveFields {
+		if strings.Contains(field, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+// Package authn authenticates the callers of the Connect API server.
+//
+// Authenticators are chained in an interceptor that stores the caller Identity in the request context.
+package authn
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+)
+
+// ErrNoCredentials is returned by an Authenticator if the request does not carry credentials it accepts.
+// The next Authenticator in the chain is tried.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authentication methods of an Identity.
+const (
+	MethodJWT    = "jwt"
+	MethodMTLS   = "mtls"
+	MethodStatic = "static"
+)
+
+// Methods are all the authentication methods.
+var Methods = []string{MethodJWT, MethodMTLS, MethodStatic}
+
+// Identity is the authenticated caller.
+type Identity struct {
+	// Subject identifies the caller, such as the JWT subject or the common name of the client certificate.
+	// It is only unique within the authentication method; use Key to identify the caller.
+	Subject string `json:"subject"`
+	// Groups are the groups the caller belongs to.
+	Groups []string `json:"groups,omitempty"`
+	// Method is the authentication method, one of MethodJWT, MethodMTLS or MethodStatic.
+	Method string `json:"-"`
+}
+
+// Key identifies the caller across the authentication methods in the form `{method}:{subject}`, such as `jwt:alice`.
+// A client certificate with the common name alice and a JWT with the subject alice are different callers.
+func (id *Identity) Key() string {
+	return id.Method + ":" + id.Subject
+}
+
+// Authenticator authenticates a request from its headers and context.
+type Authenticator interface {
+	// Authenticate returns the identity of the caller.
+	// It returns ErrNoCredentials if the request does not carry credentials the Authenticator accepts.
+	Authenticate(ctx context.Context, header http.Header) (*Identity, error)
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx that carries the identity.
+func WithIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFrom returns the identity of the caller stored by the interceptor.
+func IdentityFrom(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
+
+// NewInterceptor returns an interceptor that authenticates every request with the first Authenticator
+// that accepts its credentials. The request fails with CodeUnauthenticated if no Authenticator accepts it.
+func NewInterceptor(authenticators ...Authenticator) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			id, err := authenticate(ctx, req.Header(), authenticators)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeUnauthenticated, err)
+			}
+			return next(WithIdentity(ctx, id), req)
+		}
+	}
+}
+
+func authenticate(ctx context.Context, header http.Header, authenticators []Authenticator) (*Identity, error) {
+	for _, a := range authenticators {
+		id, err := a.Authenticate(ctx, header)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return id, nil
+	}
+	return nil, ErrNoCredentials
+}
+
+type tlsKey struct{}
+
+// Middleware stores the TLS connection state of the request in the context
+// so that the client certificates are available to the Authenticators.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			r = r.WithContext(context.WithValue(r.Context(), tlsKey{}, r.TLS))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func tlsFrom(ctx context.Context) (*tls.ConnectionState, bool) {
+	state, ok := ctx.Value(tlsKey{}).(*tls.ConnectionState)
+	return state, ok
+}
+
+// bearerToken returns the token of the "Authorization: Bearer" header.
+func bearerToken(header http.Header) (string, error) {
+	auth := header.Get("Authorization")
+	if auth == "" {
+		return "", ErrNoCredentials
+	}
+	scheme, token, ok := strings.Cut(auth, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", fmt.Errorf("%w: malformed authorization header", ErrNoCredentials)
+	}
+	return token, nil
+}
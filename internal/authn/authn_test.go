@@ -0,0 +1,160 @@
+package authn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewInterceptor(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: key.Public(), KeyID: "test", Algorithm: string(jose.ES256)}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwtAuthenticator, err := NewJWTAuthenticator(writeFile(t, "jwks.json", jwks), "test-issuer", "kaas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(c claims) string {
+		token, err := jwt.Signed(signer).Claims(c).Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	validClaims := claims{
+		Claims: jwt.Claims{
+			Subject:  "alice",
+			Issuer:   "test-issuer",
+			Audience: jwt.Audience{"kaas"},
+			Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Groups: []string{"developers"},
+	}
+	expiredClaims := validClaims
+	expiredClaims.Expiry = jwt.NewNumericDate(now.Add(-time.Hour))
+	otherAudienceClaims := validClaims
+	otherAudienceClaims.Audience = jwt.Audience{"other"}
+
+	staticAuthenticator, err := NewStaticTokenAuthenticator(writeFile(t, "tokens.yaml", []byte(`
+- token: dev-token
+  subject: bob
+  groups: [admins]
+`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "carol", Organization: []string{"operators"}}}
+	tlsCtx := context.WithValue(context.Background(), tlsKey{}, &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{cert}},
+	})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		header http.Header
+		want   *Identity
+		code   connect.Code
+	}{
+		{
+			name:   "jwt",
+			header: http.Header{"Authorization": {"Bearer " + sign(validClaims)}},
+			want:   &Identity{Subject: "alice", Groups: []string{"developers"}, Method: "jwt"},
+		},
+		{
+			name:   "expired jwt",
+			header: http.Header{"Authorization": {"Bearer " + sign(expiredClaims)}},
+			code:   connect.CodeUnauthenticated,
+		},
+		{
+			name:   "jwt for another audience",
+			header: http.Header{"Authorization": {"Bearer " + sign(otherAudienceClaims)}},
+			code:   connect.CodeUnauthenticated,
+		},
+		{
+			name:   "static token",
+			header: http.Header{"Authorization": {"Bearer dev-token"}},
+			want:   &Identity{Subject: "bob", Groups: []string{"admins"}, Method: "static"},
+		},
+		{
+			name:   "unknown static token",
+			header: http.Header{"Authorization": {"Bearer unknown"}},
+			code:   connect.CodeUnauthenticated,
+		},
+		{
+			name: "client certificate",
+			ctx:  tlsCtx,
+			want: &Identity{Subject: "carol", Groups: []string{"operators"}, Method: "mtls"},
+		},
+		{
+			name: "no credentials",
+			code: connect.CodeUnauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			var got *Identity
+			next := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				got, _ = IdentityFrom(ctx)
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+			interceptor := NewInterceptor(ClientCertAuthenticator{}, staticAuthenticator, jwtAuthenticator)
+			req := connect.NewRequest(&emptypb.Empty{})
+			for k, v := range tt.header {
+				req.Header()[k] = v
+			}
+			_, err := interceptor(next)(ctx, req)
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Fatalf("interceptor error = %v, wantCode %v", err, tt.code)
+				}
+				return
+			}
+			if tt.code != 0 {
+				t.Fatalf("interceptor error = nil, wantCode %v", tt.code)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("identity mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+)
+
+// signatureAlgorithms are the accepted JWT signature algorithms.
+var signatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTAuthenticator authenticates bearer tokens as JWTs signed by a key of a local JWKS file.
+type JWTAuthenticator struct {
+	keys     *jose.JSONWebKeySet
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewJWTAuthenticator creates a JWTAuthenticator with the keys of the JWKS file.
+// The issuer and audience claims are verified if not empty.
+func NewJWTAuthenticator(jwksFile, issuer, audience string) (*JWTAuthenticator, error) {
+	data, err := os.ReadFile(jwksFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+	var keys jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file %s: %w", jwksFile, err)
+	}
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("no keys in JWKS file %s", jwksFile)
+	}
+	return &JWTAuthenticator{
+		keys:     &keys,
+		issuer:   issuer,
+		audience: audience,
+		now:      time.Now,
+	}, nil
+}
+
+type claims struct {
+	jwt.Claims
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Authenticate verifies the signature, the expiry, the issuer and the audience of the bearer token.
+// Tokens that are not JWTs are left to the next Authenticator.
+func (a *JWTAuthenticator) Authenticate(_ context.Context, header http.Header) (*Identity, error) {
+	token, err := bearerToken(header)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Count(token, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+	parsed, err := jwt.ParseSigned(token, signatureAlgorithms)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	var c claims
+	if err := parsed.Claims(a.keys, &c); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if c.Expiry == nil {
+		return nil, fmt.Errorf("invalid token: missing exp claim")
+	}
+	expected := jwt.Expected{
+		Issuer: a.issuer,
+		Time:   a.now(),
+	}
+	if a.audience != "" {
+		expected.AnyAudience = jwt.Audience{a.audience}
+	}
+	if err := c.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("invalid token: missing sub claim")
+	}
+	return &Identity{
+		Subject: c.Subject,
+		Groups:  c.Groups,
+		Method:  MethodJWT,
+	}, nil
+}
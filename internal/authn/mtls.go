@@ -0,0 +1,29 @@
+package authn
+
+import (
+	"context"
+	"net/http"
+)
+
+// ClientCertAuthenticator authenticates the client certificate verified by the TLS handshake.
+// The subject is the common name and the groups are the organizations of the certificate.
+//
+// The server must verify the client certificates against its client CAs, and the handler must be wrapped by Middleware.
+type ClientCertAuthenticator struct{}
+
+// Authenticate returns the identity of the verified client certificate.
+func (ClientCertAuthenticator) Authenticate(ctx context.Context, _ http.Header) (*Identity, error) {
+	state, ok := tlsFrom(ctx)
+	if !ok || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, ErrNoCredentials
+	}
+	cert := state.VerifiedChains[0][0]
+	if cert.Subject.CommonName == "" {
+		return nil, ErrNoCredentials
+	}
+	return &Identity{
+		Subject: cert.Subject.CommonName,
+		Groups:  cert.Subject.Organization,
+		Method:  MethodMTLS,
+	}, nil
+}
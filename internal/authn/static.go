@@ -0,0 +1,62 @@
+package authn
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+
+	"sigs.k8s.io/yaml"
+)
+
+// StaticToken maps a bearer token to an identity.
+type StaticToken struct {
+	Token   string   `json:"token"`
+	Subject string   `json:"subject"`
+	Groups  []string `json:"groups,omitempty"`
+}
+
+// StaticTokenAuthenticator authenticates bearer tokens listed in a file.
+// It is intended for development only.
+type StaticTokenAuthenticator struct {
+	tokens []StaticToken
+}
+
+// NewStaticTokenAuthenticator creates a StaticTokenAuthenticator from a YAML file with a list of StaticToken,
+// such as `[{token: dev-token, subject: alice, groups: [developers]}]`.
+func NewStaticTokenAuthenticator(file string) (*StaticTokenAuthenticator, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static tokens file: %w", err)
+	}
+	var tokens []StaticToken
+	if err := yaml.UnmarshalStrict(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse static tokens file %s: %w", file, err)
+	}
+	for i, t := range tokens {
+		if t.Token == "" || t.Subject == "" {
+			return nil, fmt.Errorf("static token %d in %s must have a token and a subject", i, file)
+		}
+	}
+	return &StaticTokenAuthenticator{tokens: tokens}, nil
+}
+
+// Authenticate returns the identity of the matching bearer token.
+// Unknown tokens are left to the next Authenticator.
+func (a *StaticTokenAuthenticator) Authenticate(_ context.Context, header http.Header) (*Identity, error) {
+	token, err := bearerToken(header)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(token)) == 1 {
+			return &Identity{
+				Subject: t.Subject,
+				Groups:  t.Groups,
+				Method:  MethodStatic,
+			}, nil
+		}
+	}
+	return nil, ErrNoCredentials
+}
@@ -0,0 +1,150 @@
+// Package authz authorizes the callers of the Connect API server with RBAC-style roles bound per project.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"sigs.k8s.io/yaml"
+)
+
+// Role is a set of permissions on the resources of a project.
+// Each role includes the permissions of the roles below it: admin > editor > viewer.
+type Role string
+
+const (
+	// RoleViewer can read clusters and operations.
+	RoleViewer Role = "viewer"
+	// RoleEditor can additionally create, update and delete clusters.
+	RoleEditor Role = "editor"
+	// RoleAdmin can call every procedure.
+	RoleAdmin Role = "admin"
+)
+
+// AllProjects is the project of a binding that applies to every project.
+const AllProjects = "*"
+
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// procedureRoles is the minimum role required for each procedure.
+// Procedures not listed require RoleAdmin.
+var procedureRoles = map[string]Role{
+	v1alpha1connect.ClusterServiceGetClusterProcedure:                  RoleViewer,
+	v1alpha1connect.ClusterServiceListClustersProcedure:                RoleViewer,
+	v1alpha1connect.LongRunningOperationServiceGetOperationProcedure:   RoleViewer,
+	v1alpha1connect.LongRunningOperationServiceListOperationsProcedure: RoleViewer,
+	v1alpha1connect.QuotaServiceGetQuotaProcedure:                      RoleViewer,
+	v1alpha1connect.ClusterServiceCreateClusterProcedure:               RoleEditor,
+	v1alpha1connect.ClusterServiceUpdateClusterProcedure:               RoleEditor,
+	v1alpha1connect.ClusterServiceDeleteClusterProcedure:               RoleEditor,
+}
+
+// Binding grants a role in a project to subjects and groups.
+type Binding struct {
+	// Project is the project ID, or AllProjects.
+	Project string `json:"project"`
+	Role    Role   `json:"role"`
+	// Subjects are the callers in the form `{method}:{subject}` of authn.Identity.Key, such as `jwt:alice` or `mtls:alice`,
+	// so that a subject is only granted the role when authenticated by the method.
+	Subjects []string `json:"subjects,omitempty"`
+	// Groups are the groups in the form `{method}:{group}`, such as `jwt:platform`,
+	// so that a group is only granted the role when asserted by the method.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Policy is a list of role bindings. A caller is denied unless a binding grants the required role.
+type Policy struct {
+	Bindings []Binding `json:"bindings"`
+}
+
+// LoadPolicy loads a Policy from a YAML file.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var p Policy
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %w", file, err)
+	}
+	for i, b := range p.Bindings {
+		if _, ok := roleRanks[b.Role]; !ok {
+			return nil, fmt.Errorf("binding %d in %s has unknown role %q", i, file, b.Role)
+		}
+		if b.Project == "" {
+			return nil, fmt.Errorf("binding %d in %s must have a project", i, file)
+		}
+		if err := validateQualified("subject", b.Subjects); err != nil {
+			return nil, fmt.Errorf("binding %d in %s %w", i, file, err)
+		}
+		if err := validateQualified("group", b.Groups); err != nil {
+			return nil, fmt.Errorf("binding %d in %s %w", i, file, err)
+		}
+	}
+	return &p, nil
+}
+
+// validateQualified returns an error if any of the values is not qualified by an authentication method as `{method}:{kind}`.
+func validateQualified(kind string, values []string) error {
+	for _, v := range values {
+		method, name, _ := strings.Cut(v, ":")
+		if !slices.Contains(authn.Methods, method) || name == "" {
+			return fmt.Errorf("has %s %q, must be {method}:{%s} with a method of %s", kind, v, kind, strings.Join(authn.Methods, ", "))
+		}
+	}
+	return nil
+}
+
+// Authorize returns nil if the identity is granted the role required by the procedure in the project.
+func (p *Policy) Authorize(id *authn.Identity, procedure, project string) error {
+	required, ok := procedureRoles[procedure]
+	if !ok {
+		required = RoleAdmin
+	}
+	for _, b := range p.Bindings {
+		if b.Project != AllProjects && b.Project != project {
+			continue
+		}
+		if roleRanks[b.Role] < roleRanks[required] {
+			continue
+		}
+		if slices.Contains(b.Subjects, id.Key()) || slices.ContainsFunc(id.Groups, func(g string) bool {
+			return slices.Contains(b.Groups, id.Method+":"+g)
+		}) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not granted %s in project %q for %s", id.Key(), required, project, procedure)
+}
+
+// NewInterceptor returns an interceptor that authorizes every request with the policy.
+// It must be placed after the authn interceptor. The request fails with CodeUnauthenticated
+// if the caller has no identity, and with CodePermissionDenied if the policy denies it.
+func NewInterceptor(policy *Policy) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			id, ok := authn.IdentityFrom(ctx)
+			if !ok {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("authentication is required for %s", req.Spec().Procedure))
+			}
+			if err := policy.Authorize(id, req.Spec().Procedure, domain.ProjectOf(req.Any())); err != nil {
+				return nil, connect.NewError(connect.CodePermissionDenied, err)
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestNewInterceptor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte(`
+bindings:
+  - project: p1
+    role: editor
+    subjects: ["jwt:alice"]
+  - project: p2
+    role: viewer
+    groups: ["mtls:auditors"]
+  - project: "*"
+    role: admin
+    groups: ["jwt:platform"]
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := &authn.Identity{Subject: "alice", Method: authn.MethodJWT}
+	auditor := &authn.Identity{Subject: "bob", Groups: []string{"auditors"}, Method: authn.MethodMTLS}
+	platform := &authn.Identity{Subject: "carol", Groups: []string{"platform"}, Method: authn.MethodJWT}
+
+	tests := []struct {
+		name string
+		id   *authn.Identity
+		req  connect.AnyRequest
+		code connect.Code
+	}{
+		{
+			name: "editor creates a cluster in the bound project",
+			id:   alice,
+			req:  newRequest(v1alpha1connect.ClusterServiceCreateClusterProcedure, &apiv1alpha1.CreateClusterRequest{Parent: "projects/p1"}),
+		},
+		{
+			name: "editor cannot create a cluster in another project",
+			id:   alice,
+			req:  newRequest(v1alpha1connect.ClusterServiceCreateClusterProcedure, &apiv1alpha1.CreateClusterRequest{Parent: "projects/p2"}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "subject of another authentication method is not bound",
+			id:   &authn.Identity{Subject: "alice", Method: authn.MethodMTLS},
+			req:  newRequest(v1alpha1connect.ClusterServiceCreateClusterProcedure, &apiv1alpha1.CreateClusterRequest{Parent: "projects/p1"}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "viewer gets an operation by group",
+			id:   auditor,
+			req:  newRequest(v1alpha1connect.LongRunningOperationServiceGetOperationProcedure, &apiv1alpha1.GetOperationRequest{Name: "projects/p2/operations/o1"}),
+		},
+		{
+			name: "group of another authentication method is not bound",
+			id:   &authn.Identity{Subject: "dave", Groups: []string{"platform"}, Method: authn.MethodStatic},
+			req:  newRequest(v1alpha1connect.ClusterServiceDeleteClusterProcedure, &apiv1alpha1.DeleteClusterRequest{Name: "projects/p3/clusters/c1"}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "editor updates a cluster",
+			id:   alice,
+			req:  newRequest(v1alpha1connect.ClusterServiceUpdateClusterProcedure, &apiv1alpha1.UpdateClusterRequest{Cluster: &apiv1alpha1.Cluster{Name: "projects/p1/clusters/c1"}}),
+		},
+		{
+			name: "viewer cannot update a cluster",
+			id:   auditor,
+			req:  newRequest(v1alpha1connect.ClusterServiceUpdateClusterProcedure, &apiv1alpha1.UpdateClusterRequest{Cluster: &apiv1alpha1.Cluster{Name: "projects/p2/clusters/c1"}}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "viewer cannot delete a cluster",
+			id:   auditor,
+			req:  newRequest(v1alpha1connect.ClusterServiceDeleteClusterProcedure, &apiv1alpha1.DeleteClusterRequest{Name: "projects/p2/clusters/c1"}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "admin of all projects",
+			id:   platform,
+			req:  newRequest(v1alpha1connect.ClusterServiceDeleteClusterProcedure, &apiv1alpha1.DeleteClusterRequest{Name: "projects/p3/clusters/c1"}),
+		},
+		{
+			name: "unknown procedures require admin",
+			id:   alice,
+			req:  newRequest("/api.proto.v1alpha1.Unknown/Call", &emptypb.Empty{}),
+			code: connect.CodePermissionDenied,
+		},
+		{
+			name: "unauthenticated",
+			req:  newRequest(v1alpha1connect.LongRunningOperationServiceGetOperationProcedure, &apiv1alpha1.GetOperationRequest{Name: "projects/p2/operations/o1"}),
+			code: connect.CodeUnauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.id != nil {
+				ctx = authn.WithIdentity(ctx, tt.id)
+			}
+			called := false
+			next := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+				called = true
+				return connect.NewResponse(&emptypb.Empty{}), nil
+			}
+			_, err := NewInterceptor(policy)(next)(ctx, tt.req)
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Fatalf("interceptor error = %v, wantCode %v", err, tt.code)
+				}
+				return
+			}
+			if tt.code != 0 {
+				t.Fatalf("interceptor error = nil, wantCode %v", tt.code)
+			}
+			if !called {
+				t.Error("next was not called")
+			}
+		})
+	}
+}
+
+func TestLoadPolicy_qualified(t *testing.T) {
+	for _, field := range []string{"subjects", "groups"} {
+		for _, value := range []string{"alice", "ldap:alice", "jwt:"} {
+			path := filepath.Join(t.TempDir(), "policy.yaml")
+			if err := os.WriteFile(path, []byte(`
+bindings:
+  - project: p1
+    role: viewer
+    `+field+`: ["`+value+`"]
+`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPolicy(path); err == nil {
+				t.Errorf("LoadPolicy() with %s %q error = nil, want an error", field, value)
+			}
+		}
+	}
+}
+
+// request overrides the procedure of a request, which is only set by a Connect handler.
+type request[T any] struct {
+	*connect.Request[T]
+	procedure string
+}
+
+func newRequest[T any](procedure string, msg *T) connect.AnyRequest {
+	return &request[T]{Request: connect.NewRequest(msg), procedure: procedure}
+}
+
+func (r *request[T]) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
@@ -1,26 +1,84 @@
 package cli
 
 import (
+	"os"
+	"time"
+
 	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
 	"github.com/nokamoto/kaas-operator-prototype/internal/cli/cluster"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/config"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/dashboard"
 	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
 	"github.com/spf13/cobra"
 )
 
 func New() *cobra.Command {
-	var baseURL string
+	var configPath, contextName, baseURL string
+	var requestTimeout time.Duration
+	var options apiclient.Options
+	path := func() (string, error) {
+		return config.Path(configPath, os.Getenv)
+	}
+	resolve := func(cmd *cobra.Command) error {
+		p, err := path()
+		if err != nil {
+			return err
+		}
+		s, err := config.Resolve(p, contextName, os.Getenv)
+		if err != nil {
+			return err
+		}
+		options = s.Options
+		options.UserAgent = apiclient.UserAgent("kcli")
+		if cmd.Flags().Changed("url") {
+			options.BaseURL = baseURL
+		}
+		if cmd.Flags().Changed("request-timeout") {
+			options.Timeout = requestTimeout
+		}
+		return s.Apply(cmd)
+	}
 	cmd := &cobra.Command{
 		Use:   "kcli",
 		Short: "Kubernetes as a Service CLI",
+		// Errors are printed by the caller with WriteError to render the error details.
+		SilenceErrors: true,
+		// Resolve the settings of the context before the required flags such as --project are validated.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return resolve(cmd)
+		},
 	}
-	cmd.PersistentFlags().StringVar(&baseURL, "url", apiclient.DefaultBaseURL, "API endpoint URL")
+	cmd.PersistentFlags().StringVar(&configPath, "config", "", "Path of the configuration file. Defaults to $KCLI_CONFIG or ~/.config/kcli/config.yaml")
+	cmd.PersistentFlags().StringVar(&contextName, "context", "", "Name of the context of the configuration file to use. Defaults to $KCLI_CONTEXT or the current context")
+	cmd.PersistentFlags().StringVar(&baseURL, "url", "", "API endpoint URL. Defaults to $KCLI_URL, the url of the context or "+apiclient.DefaultBaseURL)
+	cmd.PersistentFlags().DurationVar(&requestTimeout, "request-timeout", 0, "Deadline of each API call including its retries. Defaults to $KCLI_REQUEST_TIMEOUT, the requestTimeout of the context or 30s. No deadline if 0")
 
-	r := apiclient.New(func() string {
-		return baseURL
+	r := apiclient.New(func() apiclient.Options {
+		return options
 	})
 	cmd.AddCommand(
 		cluster.New(r),
-		logrunningoperation.New(),
+		logrunningoperation.New(r),
+		config.New(path, &contextName),
+		dashboard.New(r),
 	)
+	resolveBeforeCompletion(cmd, resolve)
 	return cmd
 }
+
+// resolveBeforeCompletion resolves the settings before the arguments of the command and its subcommands are
+// completed with the API, since completions run without the PersistentPreRunE hook.
+func resolveBeforeCompletion(cmd *cobra.Command, resolve func(*cobra.Command) error) {
+	if complete := cmd.ValidArgsFunction; complete != nil {
+		cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if err := resolve(cmd); err != nil {
+				cobra.CompErrorln(err.Error())
+				return nil, cobra.ShellCompDirectiveError
+			}
+			return complete(cmd, args, toComplete)
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		resolveBeforeCompletion(sub, resolve)
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/config"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+type fakeClusterService struct {
+	v1alpha1connect.UnimplementedClusterServiceHandler
+}
+
+func (fakeClusterService) ListClusters(_ context.Context, req *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
+	return connect.NewResponse(&v1alpha1.ListClustersResponse{
+		Clusters: []*v1alpha1.Cluster{
+			{Name: req.Msg.GetParent() + "/clusters/c1", DisplayName: "dev"},
+		},
+	}), nil
+}
+
+func TestNew_completion(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(v1alpha1connect.NewClusterServiceHandler(fakeClusterService{}))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	// The project and the endpoint come from the environment since completions run without the PreRun hooks
+	t.Setenv(config.EnvConfig, filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv(apiclient.EnvURL, srv.URL)
+	t.Setenv(config.EnvProject, "p1")
+
+	tests := []struct {
+		name string
+		args []string
+		want func(t *testing.T, got string)
+	}{
+		{
+			name: "cluster IDs",
+			args: []string{"__complete", "cluster", "get", ""},
+			want: func(t *testing.T, got string) {
+				// cobra suggests the required flags before the settings are resolved
+				if diff := cmp.Diff("--project\tProject ID of the clusters\nc1\tdev\n:4\n", got); diff != "" {
+					t.Errorf("completion mismatch (-want +got):\n%s", diff)
+				}
+			},
+		},
+		{
+			name: "shell script",
+			args: []string{"completion", "bash"},
+			want: func(t *testing.T, got string) {
+				if !strings.Contains(got, "__start_kcli") {
+					t.Errorf("completion bash = %q, want a bash completion script", got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := New()
+			cmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			tt.want(t, out.String())
+		})
+	}
+}
@@ -1,20 +1,35 @@
 package cluster
 
 import (
+	"fmt"
+
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
 	"github.com/spf13/cobra"
 )
 
 type runtime interface {
 	ClusterService() v1alpha1connect.ClusterServiceClient
+	LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient
 }
 
 func New(r runtime) *cobra.Command {
+	var project string
 	cmd := &cobra.Command{
 		Use:     "cluster",
 		Short:   "Manage Kubernetes clusters",
 		Aliases: []string{"c"},
 	}
-	cmd.AddCommand(newCreate(r))
+	cmd.PersistentFlags().StringVar(&project, "project", "", "Project ID of the clusters")
+	_ = cmd.MarkPersistentFlagRequired("project")
+	cmd.AddCommand(newCreate(r, &project))
+	cmd.AddCommand(newGet(r, &project))
+	cmd.AddCommand(newList(r, &project))
+	cmd.AddCommand(newUpdate(r, &project))
+	cmd.AddCommand(newDelete(r, &project))
 	return cmd
 }
+
+// clusterName returns the resource name of the cluster in the project.
+func clusterName(project, clusterID string) string {
+	return fmt.Sprintf("projects/%s/clusters/%s", project, clusterID)
+}
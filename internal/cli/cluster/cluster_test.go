@@ -3,38 +3,98 @@ package cluster
 import (
 	"bytes"
 	"errors"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"buf.build/go/protoyaml"
 	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
 	mockv1alpha1 "github.com/nokamoto/kaas-operator-prototype/internal/mock/mock_v1alpha1connect"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type mockRuntime struct {
-	client *mockv1alpha1.MockClusterServiceClient
+	client     *mockv1alpha1.MockClusterServiceClient
+	operations *mockv1alpha1.MockLongRunningOperationServiceClient
 }
 
 func (m *mockRuntime) ClusterService() v1alpha1connect.ClusterServiceClient {
 	return m.client
 }
 
+func (m *mockRuntime) LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient {
+	return m.operations
+}
+
 type testcase struct {
 	name    string
 	args    []string
 	mock    func(*mockv1alpha1.MockClusterServiceClient)
+	ops     func(*mockv1alpha1.MockLongRunningOperationServiceClient)
+	stdin   string
 	want    proto.Message
+	wantOut string
 	wantErr error
 }
 
+// run executes the command of the test case and compares the decoded output with the wanted message,
+// or the output as is with wantOut for output formats that cannot be decoded.
+func run(t *testing.T, tt testcase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := mockv1alpha1.NewMockClusterServiceClient(ctrl)
+	if tt.mock != nil {
+		tt.mock(m)
+	}
+	ops := mockv1alpha1.NewMockLongRunningOperationServiceClient(ctrl)
+	if tt.ops != nil {
+		tt.ops(ops)
+	}
+
+	cmd := New(&mockRuntime{
+		client:     m,
+		operations: ops,
+	})
+	cmd.SetArgs(tt.args)
+	cmd.SetIn(strings.NewReader(tt.stdin))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); !errors.Is(err, tt.wantErr) {
+		t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+	}
+	if tt.wantOut != "" {
+		if diff := cmp.Diff(tt.wantOut, out.String()); diff != "" {
+			t.Errorf("New() output mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if tt.want == nil {
+		return
+	}
+
+	got := tt.want.ProtoReflect().New().Interface()
+	if err := protoyaml.Unmarshal(out.Bytes(), got); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("New() got = %v, want %v, diff: %s", got, tt.want, diff)
+	}
+}
+
 func TestNew_create(t *testing.T) {
 	want := &v1alpha1.LongRunningOperation{
-		Name: "operation-123",
+		Name: "projects/test-project/operations/operation-123",
 	}
 	testDisplayName := "test-cluster"
 	testDescription := "test description"
@@ -43,6 +103,7 @@ func TestNew_create(t *testing.T) {
 			name: "got long-running operation if create cluster successfully",
 			args: []string{
 				"create",
+				"--project", "test-project",
 				"--display-name", testDisplayName,
 				"--description", testDescription,
 			},
@@ -52,6 +113,61 @@ func TestNew_create(t *testing.T) {
 						DisplayName: testDisplayName,
 						Description: testDescription,
 					},
+					Parent: "projects/test-project",
+				})).Return(connect.NewResponse(want), nil)
+			},
+			want: want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mockv1alpha1.NewMockClusterServiceClient(ctrl)
+			if tt.mock != nil {
+				tt.mock(m)
+			}
+
+			cmd := New(&mockRuntime{
+				client: m,
+			})
+			cmd.SetArgs(tt.args)
+
+			var out bytes.Buffer
+			cmd.SetOutput(&out)
+			if err := cmd.Execute(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got v1alpha1.LongRunningOperation
+			if err := protoyaml.Unmarshal(out.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal output: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, &got, protocmp.Transform()); diff != "" {
+				t.Errorf("New() got = %v, want %v, diff: %s", &got, tt.want, diff)
+			}
+		})
+	}
+}
+
+func TestNew_update(t *testing.T) {
+	want := &v1alpha1.LongRunningOperation{
+		Done: true,
+	}
+	tests := []testcase{
+		{
+			name: "got long-running operation if update cluster successfully",
+			args: []string{
+				"update", "calm-falcon-7c2e",
+				"--project", "test-project",
+				"--description", "new description",
+			},
+			mock: func(m *mockv1alpha1.MockClusterServiceClient) {
+				m.EXPECT().UpdateCluster(gomock.Any(), connect.NewRequest(&v1alpha1.UpdateClusterRequest{
+					Cluster: &v1alpha1.Cluster{
+						Name:        "projects/test-project/clusters/calm-falcon-7c2e",
+						Description: "new description",
+					},
+					UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
 				})).Return(connect.NewResponse(want), nil)
 			},
 			want: want,
@@ -86,3 +202,268 @@ func TestNew_create(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_get(t *testing.T) {
+	want := &v1alpha1.Cluster{
+		Name:        "projects/test-project/clusters/calm-falcon-7c2e",
+		DisplayName: "test-cluster",
+	}
+	tests := []testcase{
+		{
+			name: "got cluster",
+			args: []string{"get", "calm-falcon-7c2e", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockClusterServiceClient) {
+				m.EXPECT().GetCluster(gomock.Any(), connect.NewRequest(&v1alpha1.GetClusterRequest{
+					Name: "projects/test-project/clusters/calm-falcon-7c2e",
+				})).Return(connect.NewResponse(want), nil)
+			},
+			want: want,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_list(t *testing.T) {
+	c1 := &v1alpha1.Cluster{Name: "projects/test-project/clusters/c1"}
+	c2 := &v1alpha1.Cluster{Name: "projects/test-project/clusters/c2"}
+	tests := []testcase{
+		{
+			name: "got clusters of all the pages",
+			args: []string{"list", "--project", "test-project", "--page-size", "1", "--filter", `display_name = "dev"`},
+			mock: func(m *mockv1alpha1.MockClusterServiceClient) {
+				gomock.InOrder(
+					m.EXPECT().ListClusters(gomock.Any(), connect.NewRequest(&v1alpha1.ListClustersRequest{
+						Parent:   "projects/test-project",
+						PageSize: 1,
+						Filter:   `display_name = "dev"`,
+					})).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{
+						Clusters:      []*v1alpha1.Cluster{c1},
+						NextPageToken: "next",
+					}), nil),
+					m.EXPECT().ListClusters(gomock.Any(), connect.NewRequest(&v1alpha1.ListClustersRequest{
+						Parent:    "projects/test-project",
+						PageSize:  1,
+						PageToken: "next",
+						Filter:    `display_name = "dev"`,
+					})).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{
+						Clusters: []*v1alpha1.Cluster{c2},
+					}), nil),
+				)
+			},
+			want: &v1alpha1.ListClustersResponse{
+				Clusters: []*v1alpha1.Cluster{c1, c2},
+			},
+		},
+		{
+			name: "failed if the server repeats a page token",
+			args: []string{"list", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockClusterServiceClient) {
+				gomock.InOrder(
+					m.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{
+						Clusters:      []*v1alpha1.Cluster{c1},
+						NextPageToken: "next",
+					}), nil),
+					m.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{
+						Clusters:      []*v1alpha1.Cluster{c2},
+						NextPageToken: "next",
+					}), nil),
+				)
+			},
+			wantErr: ErrUnendingPages,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_delete(t *testing.T) {
+	want := &v1alpha1.LongRunningOperation{
+		Name: "projects/test-project/operations/cluster-delete-123",
+	}
+	expectDelete := func(m *mockv1alpha1.MockClusterServiceClient) {
+		m.EXPECT().DeleteCluster(gomock.Any(), connect.NewRequest(&v1alpha1.DeleteClusterRequest{
+			Name: "projects/test-project/clusters/calm-falcon-7c2e",
+		})).Return(connect.NewResponse(want), nil)
+	}
+	tests := []testcase{
+		{
+			name: "deleted without confirmation if --yes",
+			args: []string{"delete", "calm-falcon-7c2e", "--project", "test-project", "--yes"},
+			mock: expectDelete,
+			want: want,
+		},
+		{
+			name:  "deleted if confirmed",
+			args:  []string{"delete", "calm-falcon-7c2e", "--project", "test-project"},
+			stdin: "y\n",
+			mock:  expectDelete,
+			want:  want,
+		},
+		{
+			name: "printed the result as a table once deleted if --wait",
+			args: []string{"delete", "calm-falcon-7c2e", "--project", "test-project", "--yes", "--wait", "-o", "table"},
+			mock: expectDelete,
+			ops: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				done := &v1alpha1.LongRunningOperation{Name: want.GetName(), Done: true}
+				var err error
+				if done.Response, err = anypb.New(&emptypb.Empty{}); err != nil {
+					t.Fatal(err)
+				}
+				m.EXPECT().GetOperation(gomock.Any(), connect.NewRequest(&v1alpha1.GetOperationRequest{
+					Name: want.GetName(),
+				})).Return(connect.NewResponse(done), nil)
+			},
+			wantOut: "RESULT\ndone\n",
+		},
+		{
+			name:    "aborted if not confirmed",
+			args:    []string{"delete", "calm-falcon-7c2e", "--project", "test-project"},
+			stdin:   "n\n",
+			wantErr: ErrAborted,
+		},
+		{
+			name:    "aborted if no answer",
+			args:    []string{"delete", "calm-falcon-7c2e", "--project", "test-project"},
+			wantErr: io.EOF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_createWait(t *testing.T) {
+	backoff := logrunningoperation.DefaultBackoff
+	logrunningoperation.DefaultBackoff = logrunningoperation.Backoff{Initial: time.Millisecond, Max: time.Millisecond, Factor: 1}
+	t.Cleanup(func() { logrunningoperation.DefaultBackoff = backoff })
+
+	testName := "projects/test-project/operations/cluster-create-123"
+	cluster := &v1alpha1.Cluster{
+		Name:        "projects/test-project/clusters/calm-falcon-7c2e",
+		DisplayName: "test-cluster",
+	}
+	operation := func(phase string, done bool, response proto.Message) *v1alpha1.LongRunningOperation {
+		op := &v1alpha1.LongRunningOperation{Name: testName, Done: done}
+		var err error
+		if op.Metadata, err = anypb.New(&v1alpha1.LongRunningOperation_Pipeline{
+			Status: &v1alpha1.LongRunningOperation_Pipeline_Status{Phase: phase},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if response != nil {
+			if op.Response, err = anypb.New(response); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return op
+	}
+	create := func(m *mockv1alpha1.MockClusterServiceClient) {
+		m.EXPECT().CreateCluster(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.LongRunningOperation{Name: testName}), nil)
+	}
+	get := func(ops ...*v1alpha1.LongRunningOperation) func(*mockv1alpha1.MockLongRunningOperationServiceClient) {
+		return func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+			var calls []any
+			for _, op := range ops {
+				calls = append(calls, m.EXPECT().GetOperation(gomock.Any(), connect.NewRequest(&v1alpha1.GetOperationRequest{
+					Name: testName,
+				})).Return(connect.NewResponse(op), nil))
+			}
+			gomock.InOrder(calls...)
+		}
+	}
+	tests := []testcase{
+		{
+			name: "got cluster once the operation is done",
+			args: []string{"create", "--project", "test-project", "--wait"},
+			mock: create,
+			ops:  get(operation("Running", false, nil), operation("Succeeded", true, cluster)),
+			want: cluster,
+		},
+		{
+			name:    "failed if the operation has failed",
+			args:    []string{"create", "--project", "test-project", "--wait"},
+			mock:    create,
+			ops:     get(operation("Failed", true, &emptypb.Empty{})),
+			wantErr: logrunningoperation.ErrOperationFailed,
+		},
+		{
+			name: "timed out if the operation is not done in time",
+			args: []string{"create", "--project", "test-project", "--wait", "--timeout", "10ms"},
+			mock: create,
+			ops: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().GetOperation(gomock.Any(), gomock.Any()).Return(connect.NewResponse(operation("Running", false, nil)), nil).AnyTimes()
+			},
+			wantErr: logrunningoperation.ErrTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_complete(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		mock func(*mockv1alpha1.MockClusterServiceClient)
+		want string
+	}{
+		{
+			name: "complete the clusters",
+			args: []string{"__complete", "delete", "--project", "test-project", "c"},
+			mock: func(m *mockv1alpha1.MockClusterServiceClient) {
+				m.EXPECT().ListClusters(gomock.Any(), connect.NewRequest(&v1alpha1.ListClustersRequest{
+					Parent: "projects/test-project",
+				})).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{
+					Clusters: []*v1alpha1.Cluster{
+						{Name: "projects/test-project/clusters/c1", DisplayName: "dev"},
+						{Name: "projects/test-project/clusters/c2"},
+						{Name: "projects/test-project/clusters/prod"},
+					},
+				}), nil)
+			},
+			want: "c1\tdev\nc2\n:4\n",
+		},
+		{
+			name: "complete nothing without the project",
+			args: []string{"__complete", "get", ""},
+			want: "--project\tProject ID of the clusters\n:4\n",
+		},
+		{
+			name: "complete the output formats",
+			args: []string{"__complete", "get", "--project", "test-project", "-o", "ta"},
+			want: "table\tTable of the main fields\n:4\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mockv1alpha1.NewMockClusterServiceClient(ctrl)
+			if tt.mock != nil {
+				tt.mock(m)
+			}
+			cmd := New(&mockRuntime{client: m})
+			cmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("completion mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
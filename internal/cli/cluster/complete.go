@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"path"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// completeClusterID completes the first argument with the IDs of the clusters in the project,
+// described by their display names. Only the first page of the clusters is completed.
+func completeClusterID(r runtime, project *string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 || *project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		res, err := r.ClusterService().ListClusters(cmd.Context(), connect.NewRequest(&v1alpha1.ListClustersRequest{
+			Parent: "projects/" + *project,
+		}))
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var completions []cobra.Completion
+		for _, c := range res.Msg.GetClusters() {
+			id := path.Base(c.GetName())
+			if strings.HasPrefix(id, toComplete) {
+				completions = append(completions, cobra.CompletionWithDesc(id, c.GetDisplayName()))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -9,9 +9,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func newCreate(r runtime) *cobra.Command {
-	var displayName, description string
+func newCreate(r runtime, project *string) *cobra.Command {
+	var clusterID, displayName, description, requestID string
 	var out encode.Encoder
+	var wait waitFlags
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new Kubernetes cluster",
@@ -22,16 +23,21 @@ func newCreate(r runtime) *cobra.Command {
 					DisplayName: displayName,
 					Description: description,
 				},
+				Parent:    "projects/" + *project,
+				RequestId: requestID,
+				ClusterId: clusterID,
 			}))
 			if err != nil {
 				return fmt.Errorf("failed to create cluster: %w", err)
 			}
-			out.Print(cmd, res.Msg)
-			return nil
+			return wait.print(cmd, r, &out, res.Msg)
 		},
 	}
+	cmd.Flags().StringVar(&clusterID, "cluster-id", "", "ID of the cluster. A human-readable ID is generated if empty")
 	cmd.Flags().StringVar(&displayName, "display-name", "", "Display name for the cluster")
 	cmd.Flags().StringVar(&description, "description", "", "Description for the cluster")
+	cmd.Flags().StringVar(&requestID, "request-id", "", "Unique ID of the request to safely retry it without creating another cluster")
+	wait.bind(cmd)
 	out.VarP(cmd)
 	return cmd
 }
@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// ErrAborted is returned when the user declines the confirmation prompt.
+var ErrAborted = errors.New("aborted")
+
+func newDelete(r runtime, project *string) *cobra.Command {
+	var yes bool
+	var out encode.Encoder
+	var wait waitFlags
+	cmd := &cobra.Command{
+		Use:               "delete CLUSTER_ID",
+		Short:             "Delete a Kubernetes cluster",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeClusterID(r, project),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := clusterName(*project, args[0])
+			if !yes {
+				ok, err := confirm(cmd, fmt.Sprintf("Delete cluster %s?", name))
+				if err != nil {
+					return err
+				}
+				if !ok {
+					return ErrAborted
+				}
+			}
+			service := r.ClusterService()
+			res, err := service.DeleteCluster(cmd.Context(), connect.NewRequest(&v1alpha1.DeleteClusterRequest{
+				Name: name,
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to delete cluster: %w", err)
+			}
+			return wait.print(cmd, r, &out, res.Msg)
+		},
+	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete without asking for confirmation")
+	wait.bind(cmd)
+	out.VarP(cmd)
+	return cmd
+}
+
+// confirm asks the question on stderr and reads the answer from stdin. Only "y" or "yes" confirms.
+func confirm(cmd *cobra.Command, question string) (bool, error) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("failed to read confirmation, use --yes to skip it: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
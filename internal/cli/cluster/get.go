@@ -0,0 +1,32 @@
+package cluster
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func newGet(r runtime, project *string) *cobra.Command {
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:               "get CLUSTER_ID",
+		Short:             "Get a Kubernetes cluster",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeClusterID(r, project),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := r.ClusterService()
+			res, err := service.GetCluster(cmd.Context(), connect.NewRequest(&v1alpha1.GetClusterRequest{
+				Name: clusterName(*project, args[0]),
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to get cluster: %w", err)
+			}
+			return out.Print(cmd, res.Msg)
+		},
+	}
+	out.VarP(cmd)
+	return cmd
+}
@@ -0,0 +1,44 @@
+package cluster
+
+import (
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func newList(r runtime, project *string) *cobra.Command {
+	var filter string
+	var pageSize int32
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the Kubernetes clusters in the project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Follow the page tokens until the last page to print all the clusters at once,
+			// or every page as soon as it is retrieved if the output format is streaming
+			all := &v1alpha1.ListClustersResponse{}
+			err := ListPages(cmd.Context(), r.ClusterService(), &v1alpha1.ListClustersRequest{
+				Parent:   "projects/" + *project,
+				PageSize: pageSize,
+				Filter:   filter,
+			}, func(res *v1alpha1.ListClustersResponse) error {
+				if out.Streaming() {
+					return out.Print(cmd, &v1alpha1.ListClustersResponse{Clusters: res.GetClusters()})
+				}
+				all.Clusters = append(all.Clusters, res.GetClusters()...)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if out.Streaming() {
+				return nil
+			}
+			return out.Print(cmd, all)
+		},
+	}
+	cmd.Flags().StringVar(&filter, "filter", "", `Expression to filter the clusters by, such as 'display_name = "dev"'`)
+	cmd.Flags().Int32Var(&pageSize, "page-size", 0, "Number of clusters to request per page. All the pages are retrieved regardless. The server chooses if 0")
+	out.VarP(cmd)
+	return cmd
+}
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func newUpdate(r runtime, project *string) *cobra.Command {
+	var displayName, description string
+	var out encode.Encoder
+	var wait waitFlags
+	cmd := &cobra.Command{
+		Use:               "update CLUSTER_ID",
+		Short:             "Update the display name or description of a Kubernetes cluster",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeClusterID(r, project),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Only the fields given by flags are updated
+			var paths []string
+			if cmd.Flags().Changed("display-name") {
+				paths = append(paths, "display_name")
+			}
+			if cmd.Flags().Changed("description") {
+				paths = append(paths, "description")
+			}
+			if len(paths) == 0 {
+				return errors.New("at least one of --display-name or --description is required")
+			}
+			service := r.ClusterService()
+			res, err := service.UpdateCluster(cmd.Context(), connect.NewRequest(&v1alpha1.UpdateClusterRequest{
+				Cluster: &v1alpha1.Cluster{
+					Name:        clusterName(*project, args[0]),
+					DisplayName: displayName,
+					Description: description,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: paths},
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to update cluster: %w", err)
+			}
+			return wait.print(cmd, r, &out, res.Msg)
+		},
+	}
+	cmd.Flags().StringVar(&displayName, "display-name", "", "New display name for the cluster")
+	cmd.Flags().StringVar(&description, "description", "", "New description for the cluster")
+	wait.bind(cmd)
+	out.VarP(cmd)
+	return cmd
+}
@@ -0,0 +1,40 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// waitFlags follows the operation returned by a mutating command to completion if --wait is set.
+type waitFlags struct {
+	wait    bool
+	timeout time.Duration
+}
+
+func (f *waitFlags) bind(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.wait, "wait", false, "Wait for the operation to be done and print its response instead of the operation")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 0, "Maximum duration to wait for with --wait. Waits indefinitely if 0")
+}
+
+// print prints the operation, or waits for it and prints its response such as the Cluster if --wait is set.
+// The progress of the operation is written to stderr while waiting.
+func (f *waitFlags) print(cmd *cobra.Command, r runtime, out *encode.Encoder, op *v1alpha1.LongRunningOperation) error {
+	if !f.wait {
+		return out.Print(cmd, op)
+	}
+	// A failed or timed out operation is not a usage error
+	cmd.SilenceUsage = true
+	op, err := logrunningoperation.Wait(cmd.Context(), r.LongRunningOperationService(), op, f.timeout, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+	res, err := logrunningoperation.Response(op)
+	if err != nil {
+		return err
+	}
+	return out.Print(cmd, res)
+}
@@ -0,0 +1,36 @@
+// Package config implements the kcli configuration file with named contexts and the commands to edit it.
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// New returns the config command that edits the configuration file at the path returned by path.
+// contextName is the --context flag of the root command, which selects the context to edit with set.
+func New(path func() (string, error), contextName *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the contexts of the kcli configuration file",
+		// Override the hook of the root command, which fails if the selected context does not exist yet
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			return nil
+		},
+	}
+	cmd.AddCommand(newGetContexts(path))
+	cmd.AddCommand(newUseContext(path))
+	cmd.AddCommand(newSet(path, contextName))
+	return cmd
+}
+
+// load returns the configuration file and its path.
+func load(path func() (string, error)) (*Config, string, error) {
+	p, err := path()
+	if err != nil {
+		return nil, "", err
+	}
+	c, err := Load(p)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, p, nil
+}
@@ -0,0 +1,391 @@
+package config
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/spf13/cobra"
+)
+
+// env returns a getenv function backed by the map.
+func env(m map[string]string) func(string) string {
+	return func(key string) string {
+		return m[key]
+	}
+}
+
+func writeConfig(t *testing.T, c *Config) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kcli", "config.yaml")
+	if err := c.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+var testConfig = &Config{
+	CurrentContext: "dev",
+	Contexts: []*Context{
+		{Name: "dev", URL: "http://localhost:8080", Project: "p1"},
+		{Name: "prod", URL: "https://kaas.example.com", Project: "p2", Token: "secret", Output: "table"},
+	},
+}
+
+// withDefaults returns the options with the zero fields set to apiclient.DefaultOptions.
+func withDefaults(o apiclient.Options) apiclient.Options {
+	d := apiclient.DefaultOptions()
+	if o.Timeout == 0 {
+		o.Timeout = d.Timeout
+	}
+	if o.Retries == 0 {
+		o.Retries = d.Retries
+	}
+	if o.Protocol == "" {
+		o.Protocol = d.Protocol
+	}
+	return o
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "flag",
+			path: "/etc/kcli.yaml",
+			env:  map[string]string{EnvConfig: "/tmp/kcli.yaml"},
+			want: "/etc/kcli.yaml",
+		},
+		{
+			name: "env",
+			env:  map[string]string{EnvConfig: "/tmp/kcli.yaml"},
+			want: "/tmp/kcli.yaml",
+		},
+		{
+			name: "XDG_CONFIG_HOME",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/home/u/.xdg"},
+			want: "/home/u/.xdg/kcli/config.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Path(tt.path, env(tt.env))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Path() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, testConfig)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("Save() permission = %o, want 600", perm)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testConfig, got); diff != "" {
+		t.Errorf("Load() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&Config{}, got); diff != "" {
+		t.Errorf("Load() of a missing file mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("contexts:\n- name: dev\n  server: http://localhost\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(invalid); err == nil {
+		t.Error("Load() of an unknown field error = nil, want an error")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	path := writeConfig(t, &Config{
+		CurrentContext: testConfig.CurrentContext,
+		Contexts:       append(slices.Clone(testConfig.Contexts), &Context{Name: "invalid", Protocol: "http3"}),
+	})
+	tests := []struct {
+		name    string
+		context string
+		env     map[string]string
+		want    *Settings
+		wantErr bool
+	}{
+		{
+			name: "current context",
+			want: &Settings{
+				Context: "dev",
+				Options: withDefaults(apiclient.Options{BaseURL: "http://localhost:8080"}),
+				Project: "p1",
+			},
+		},
+		{
+			name:    "context selected by name",
+			context: "prod",
+			env:     map[string]string{EnvContext: "dev"},
+			want: &Settings{
+				Context: "prod",
+				Options: withDefaults(apiclient.Options{BaseURL: "https://kaas.example.com", Token: "secret"}),
+				Project: "p2",
+				Output:  "table",
+			},
+		},
+		{
+			name: "context selected by env",
+			env:  map[string]string{EnvContext: "prod"},
+			want: &Settings{
+				Context: "prod",
+				Options: withDefaults(apiclient.Options{BaseURL: "https://kaas.example.com", Token: "secret"}),
+				Project: "p2",
+				Output:  "table",
+			},
+		},
+		{
+			name: "env overrides the context",
+			env: map[string]string{
+				apiclient.EnvURL:            "http://127.0.0.1:9090",
+				apiclient.EnvToken:          "env-token",
+				apiclient.EnvRequestTimeout: "1m",
+				apiclient.EnvProtocol:       "grpc",
+				EnvProject:                  "p3",
+				EnvOutput:                   "json",
+			},
+			want: &Settings{
+				Context: "dev",
+				Options: withDefaults(apiclient.Options{
+					BaseURL:  "http://127.0.0.1:9090",
+					Token:    "env-token",
+					Timeout:  time.Minute,
+					Protocol: apiclient.ProtocolGRPC,
+				}),
+				Project: "p3",
+				Output:  "json",
+			},
+		},
+		{
+			name:    "unknown context",
+			context: "staging",
+			wantErr: true,
+		},
+		{
+			name:    "invalid env",
+			env:     map[string]string{apiclient.EnvRetries: "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid context",
+			context: "invalid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(path, tt.context, env(tt.env))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Resolve() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSettings_Apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		settings    *Settings
+		wantProject string
+		wantOutput  string
+		wantErr     bool
+	}{
+		{
+			name:        "defaults from the settings",
+			settings:    &Settings{Project: "p1", Output: "table"},
+			wantProject: "p1",
+			wantOutput:  "table",
+		},
+		{
+			name:        "flags take precedence",
+			args:        []string{"--project", "p2", "--output", "json"},
+			settings:    &Settings{Project: "p1", Output: "table"},
+			wantProject: "p2",
+			wantOutput:  "json",
+		},
+		{
+			name:     "invalid output",
+			settings: &Settings{Output: "tbl"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var project string
+			var out encode.Encoder
+			cmd := &cobra.Command{}
+			cmd.Flags().StringVar(&project, "project", "", "")
+			out.VarP(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.settings.Apply(cmd); (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if project != tt.wantProject {
+				t.Errorf("project = %q, want %q", project, tt.wantProject)
+			}
+			if out.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		context string
+		args    []string
+		want    *Config
+		wantOut string
+		wantErr bool
+	}{
+		{
+			name:    "get contexts",
+			config:  testConfig,
+			args:    []string{"get-contexts"},
+			want:    testConfig,
+			wantOut: "CURRENT   NAME   URL                        PROJECT   OUTPUT\n*         dev    http://localhost:8080      p1        \n          prod   https://kaas.example.com   p2        table\n",
+		},
+		{
+			name:   "use context",
+			config: testConfig,
+			args:   []string{"use-context", "prod"},
+			want: &Config{
+				CurrentContext: "prod",
+				Contexts:       testConfig.Contexts,
+			},
+			wantOut: "Switched to context \"prod\".\n",
+		},
+		{
+			name:    "use unknown context",
+			config:  testConfig,
+			args:    []string{"use-context", "staging"},
+			want:    testConfig,
+			wantErr: true,
+		},
+		{
+			name:   "set the current context",
+			config: testConfig,
+			args:   []string{"set", "project", "p3"},
+			want: &Config{
+				CurrentContext: "dev",
+				Contexts: []*Context{
+					{Name: "dev", URL: "http://localhost:8080", Project: "p3"},
+					testConfig.Contexts[1],
+				},
+			},
+			wantOut: "Set project of context \"dev\".\n",
+		},
+		{
+			name:    "set a new context selected by --context",
+			config:  &Config{},
+			context: "dev",
+			args:    []string{"set", "url", "http://localhost:8080"},
+			want: &Config{
+				CurrentContext: "dev",
+				Contexts: []*Context{
+					{Name: "dev", URL: "http://localhost:8080"},
+				},
+			},
+			wantOut: "Set url of context \"dev\".\n",
+		},
+		{
+			name:    "set without a context",
+			config:  &Config{},
+			args:    []string{"set", "url", "http://localhost:8080"},
+			want:    &Config{},
+			wantErr: true,
+		},
+		{
+			name:    "set an unknown key",
+			config:  testConfig,
+			args:    []string{"set", "server", "http://localhost:8080"},
+			want:    testConfig,
+			wantErr: true,
+		},
+		{
+			name:    "set an invalid protocol",
+			config:  testConfig,
+			args:    []string{"set", "protocol", "http3"},
+			want:    testConfig,
+			wantErr: true,
+		},
+		{
+			name:    "set an invalid output",
+			config:  testConfig,
+			args:    []string{"set", "output", "tbl"},
+			want:    testConfig,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.config)
+			cmd := New(func() (string, error) {
+				return path, nil
+			}, &tt.context)
+			cmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(io.Discard)
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr {
+				if diff := cmp.Diff(tt.wantOut, out.String()); diff != "" {
+					t.Errorf("New() output mismatch (-want +got):\n%s", diff)
+				}
+			}
+			got, err := Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("New() config mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
+	"sigs.k8s.io/yaml"
+)
+
+// Environment variables that select the configuration and override the settings of the context.
+// The connection settings are overridden by the apiclient environment variables such as apiclient.EnvURL.
+const (
+	EnvConfig  = "KCLI_CONFIG"
+	EnvContext = "KCLI_CONTEXT"
+	EnvProject = "KCLI_PROJECT"
+	EnvOutput  = "KCLI_OUTPUT"
+)
+
+// Config is the kcli configuration file, ~/.config/kcli/config.yaml by default.
+type Config struct {
+	// CurrentContext is the name of the context used unless another one is selected.
+	CurrentContext string     `json:"currentContext,omitempty"`
+	Contexts       []*Context `json:"contexts,omitempty"`
+}
+
+// Context is a named set of settings to call an API server as a caller.
+type Context struct {
+	Name string `json:"name"`
+	// URL is the base URL of the API server.
+	URL string `json:"url,omitempty"`
+	// Project is the default project ID of the commands.
+	Project string `json:"project,omitempty"`
+	// Token is sent as a bearer token.
+	Token string `json:"token,omitempty"`
+	// CertificateAuthority is the path of the PEM encoded certificates to verify the server with.
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// ClientCertificate and ClientKey are the paths of the PEM encoded client certificate and key for mutual TLS.
+	ClientCertificate string `json:"clientCertificate,omitempty"`
+	ClientKey         string `json:"clientKey,omitempty"`
+	// RequestTimeout is the deadline of each call, such as "30s".
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+	// Protocol is one of "connect", "grpc" or "grpcweb".
+	Protocol string `json:"protocol,omitempty"`
+	// Output is the default output format of the commands, such as "table".
+	Output string `json:"output,omitempty"`
+}
+
+// apply overrides the options with the settings of the context that are set.
+func (c *Context) apply(o *apiclient.Options) error {
+	for _, f := range []struct {
+		value string
+		field *string
+	}{
+		{c.URL, &o.BaseURL},
+		{c.Token, &o.Token},
+		{c.CertificateAuthority, &o.CertificateAuthority},
+		{c.ClientCertificate, &o.ClientCertificate},
+		{c.ClientKey, &o.ClientKey},
+	} {
+		if f.value != "" {
+			*f.field = f.value
+		}
+	}
+	if c.RequestTimeout != "" {
+		d, err := time.ParseDuration(c.RequestTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid requestTimeout of context %q: %w", c.Name, err)
+		}
+		o.Timeout = d
+	}
+	if c.Protocol != "" {
+		p, err := apiclient.ParseProtocol(c.Protocol)
+		if err != nil {
+			return fmt.Errorf("invalid protocol of context %q: %w", c.Name, err)
+		}
+		o.Protocol = p
+	}
+	return nil
+}
+
+// Context returns the context of the name, or nil if not found.
+func (c *Config) Context(name string) *Context {
+	for _, ctx := range c.Contexts {
+		if ctx.Name == name {
+			return ctx
+		}
+	}
+	return nil
+}
+
+// Path returns the path of the configuration file: the given path if not empty, $KCLI_CONFIG if set,
+// or kcli/config.yaml in $XDG_CONFIG_HOME, which defaults to ~/.config.
+func Path(path string, getenv func(string) string) (string, error) {
+	if path != "" {
+		return path, nil
+	}
+	if path := getenv(EnvConfig); path != "" {
+		return path, nil
+	}
+	dir := getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the configuration file: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "kcli", "config.yaml"), nil
+}
+
+// Load reads the configuration file. A missing file is an empty configuration.
+func Load(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var c Config
+	if err := yaml.UnmarshalStrict(b, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the configuration file, creating its directory if needed.
+// The file is only readable by the user since it may contain tokens.
+func (c *Config) Save(path string) error {
+	b, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the configuration: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
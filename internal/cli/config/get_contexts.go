@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func newGetContexts(path func() (string, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts of the configuration file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, _, err := load(path)
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tURL\tPROJECT\tOUTPUT")
+			for _, ctx := range c.Contexts {
+				current := ""
+				if ctx.Name == c.CurrentContext {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", current, ctx.Name, ctx.URL, ctx.Project, ctx.Output)
+			}
+			return w.Flush()
+		},
+	}
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/spf13/cobra"
+)
+
+// keys are the settings of a context that set can change.
+var keys = map[string]func(*Context) *string{
+	"url":                   func(c *Context) *string { return &c.URL },
+	"project":               func(c *Context) *string { return &c.Project },
+	"token":                 func(c *Context) *string { return &c.Token },
+	"certificate-authority": func(c *Context) *string { return &c.CertificateAuthority },
+	"client-certificate":    func(c *Context) *string { return &c.ClientCertificate },
+	"client-key":            func(c *Context) *string { return &c.ClientKey },
+	"request-timeout":       func(c *Context) *string { return &c.RequestTimeout },
+	"protocol":              func(c *Context) *string { return &c.Protocol },
+	"output":                func(c *Context) *string { return &c.Output },
+}
+
+// validators reject the invalid values of the keys before they are saved.
+var validators = map[string]func(string) error{
+	"request-timeout": func(v string) error {
+		_, err := time.ParseDuration(v)
+		return err
+	},
+	"protocol": func(v string) error {
+		_, err := apiclient.ParseProtocol(v)
+		return err
+	},
+	"output": func(v string) error {
+		var out encode.Encoder
+		return out.Set(v)
+	},
+}
+
+func keyNames() []string {
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func newSet(path func() (string, error), contextName *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set a setting of a context of the configuration file",
+		Long: fmt.Sprintf(`Set a setting of the context selected by --context, or the current context.
+The context is created if it does not exist, and becomes the current context if there is none.
+An empty VALUE unsets the setting. KEY is one of %s.`, strings.Join(keyNames(), ", ")),
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: keyNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, value := args[0], args[1]
+			field, ok := keys[key]
+			if !ok {
+				return fmt.Errorf("unknown key %q, must be one of %s", key, strings.Join(keyNames(), ", "))
+			}
+			if validate, ok := validators[key]; ok && value != "" {
+				if err := validate(value); err != nil {
+					return fmt.Errorf("invalid %s: %w", key, err)
+				}
+			}
+			c, p, err := load(path)
+			if err != nil {
+				return err
+			}
+			name := *contextName
+			if name == "" {
+				name = c.CurrentContext
+			}
+			if name == "" {
+				return errors.New("no current context, use --context to select the context to set")
+			}
+			ctx := c.Context(name)
+			if ctx == nil {
+				ctx = &Context{Name: name}
+				c.Contexts = append(c.Contexts, ctx)
+			}
+			if c.CurrentContext == "" {
+				c.CurrentContext = name
+			}
+			*field(ctx) = value
+			if err := c.Save(p); err != nil {
+				return err
+			}
+			cmd.Printf("Set %s of context %q.\n", key, name)
+			return nil
+		},
+	}
+}
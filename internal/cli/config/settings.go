@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/apiclient"
+	"github.com/spf13/cobra"
+)
+
+// Settings are the effective settings of a command.
+// The settings of the context are overridden by the environment variables, and then by the flags.
+type Settings struct {
+	// Context is the name of the selected context, or empty if none.
+	Context string
+	Options apiclient.Options
+	Project string
+	Output  string
+}
+
+// Resolve returns the settings of the context selected by the name, $KCLI_CONTEXT or the current context
+// of the configuration file, on top of apiclient.DefaultOptions and overridden by the environment variables.
+func Resolve(path, name string, getenv func(string) string) (*Settings, error) {
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = getenv(EnvContext)
+	}
+	if name == "" {
+		name = c.CurrentContext
+	}
+	ctx := &Context{}
+	if name != "" {
+		if ctx = c.Context(name); ctx == nil {
+			return nil, fmt.Errorf("context %q is not found in %s", name, path)
+		}
+	}
+	options := apiclient.DefaultOptions()
+	if err := ctx.apply(&options); err != nil {
+		return nil, err
+	}
+	options, err = options.WithEnv(getenv)
+	if err != nil {
+		return nil, err
+	}
+	s := &Settings{
+		Context: name,
+		Options: options,
+		Project: ctx.Project,
+		Output:  ctx.Output,
+	}
+	if v := getenv(EnvProject); v != "" {
+		s.Project = v
+	}
+	if v := getenv(EnvOutput); v != "" {
+		s.Output = v
+	}
+	return s, nil
+}
+
+// Apply sets the --project and --output flags of the command to the settings unless they are set explicitly.
+func (s *Settings) Apply(cmd *cobra.Command) error {
+	for name, value := range map[string]string{
+		"project": s.Project,
+		"output":  s.Output,
+	} {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed || value == "" {
+			continue
+		}
+		// Set through the flag set to mark the flag as changed, which satisfies a required flag
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid default %s %q: %w", name, value, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func newUseContext(path func() (string, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use-context CONTEXT_NAME",
+		Short: "Set the current context of the configuration file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, p, err := load(path)
+			if err != nil {
+				return err
+			}
+			if c.Context(args[0]) == nil {
+				return fmt.Errorf("context %q is not found in %s", args[0], p)
+			}
+			c.CurrentContext = args[0]
+			if err := c.Save(p); err != nil {
+				return err
+			}
+			cmd.Printf("Switched to context %q.\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,44 @@
+// Package dashboard implements kcli dashboard, a terminal UI to watch and manage the clusters and operations of a project.
+package dashboard
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"github.com/spf13/cobra"
+)
+
+type runtime interface {
+	ClusterService() v1alpha1connect.ClusterServiceClient
+	LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient
+}
+
+func New(r runtime) *cobra.Command {
+	var project string
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Watch and manage the clusters and operations of a project in a terminal UI",
+		Long: `Watch and manage the clusters and operations of a project in a terminal UI.
+The clusters and operations are refreshed every interval. Select an operation to see the conditions of its pipeline,
+create a cluster with "c" and delete the selected one with "d".`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p := tea.NewProgram(
+				newModel(cmd.Context(), r, project, interval),
+				tea.WithContext(cmd.Context()),
+				tea.WithInput(cmd.InOrStdin()),
+				tea.WithOutput(cmd.OutOrStdout()),
+				tea.WithAltScreen(),
+			)
+			_, err := p.Run()
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&project, "project", "", "Project ID of the clusters and operations")
+	_ = cmd.MarkFlagRequired("project")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Interval between the refreshes of the clusters and operations. Refreshes only with \"r\" if 0")
+	return cmd
+}
@@ -0,0 +1,284 @@
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	tea "github.com/charmbracelet/bubbletea"
+	mockv1alpha1 "github.com/nokamoto/kaas-operator-prototype/internal/mock/mock_v1alpha1connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type mockRuntime struct {
+	clusters   *mockv1alpha1.MockClusterServiceClient
+	operations *mockv1alpha1.MockLongRunningOperationServiceClient
+}
+
+func (m *mockRuntime) ClusterService() v1alpha1connect.ClusterServiceClient {
+	return m.clusters
+}
+
+func (m *mockRuntime) LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient {
+	return m.operations
+}
+
+const testProject = "test-project"
+
+var (
+	testClusters = []*v1alpha1.Cluster{
+		{Name: "projects/test-project/clusters/c1", DisplayName: "dev"},
+		{Name: "projects/test-project/clusters/c2", DisplayName: "prod"},
+	}
+	testTime = timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+)
+
+func testOperation(t *testing.T, name, phase string, done bool, conditions ...string) *v1alpha1.LongRunningOperation {
+	t.Helper()
+	p := &v1alpha1.LongRunningOperation_Pipeline{
+		Namespace: "project-test-project",
+		Spec:      &v1alpha1.LongRunningOperation_Pipeline_Spec{Name: "c3", DisplayName: "staging"},
+		Status:    &v1alpha1.LongRunningOperation_Pipeline_Status{Phase: phase},
+	}
+	for _, c := range conditions {
+		p.Status.Conditions = append(p.Status.Conditions, &v1alpha1.LongRunningOperation_Pipeline_Status_Condition{
+			Message:            c,
+			LastTransitionTime: testTime,
+		})
+	}
+	m, err := anypb.New(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v1alpha1.LongRunningOperation{Name: "projects/test-project/operations/" + name, Done: done, Metadata: m}
+}
+
+func newTestModel(t *testing.T) (model, *mockRuntime) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	r := &mockRuntime{
+		clusters:   mockv1alpha1.NewMockClusterServiceClient(ctrl),
+		operations: mockv1alpha1.NewMockLongRunningOperationServiceClient(ctrl),
+	}
+	// No periodic refresh so that the commands of a test are only those triggered by its messages
+	return newModel(context.Background(), r, testProject, 0), r
+}
+
+// run runs the command and the commands of a batch, and returns their messages.
+// Commands that do not return within a short time, such as the cursor blinks of the form, are ignored.
+func run(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	ch := make(chan tea.Msg, 1)
+	go func() {
+		ch <- cmd()
+	}()
+	select {
+	case msg := <-ch:
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			var msgs []tea.Msg
+			for _, cmd := range batch {
+				msgs = append(msgs, run(cmd)...)
+			}
+			return msgs
+		}
+		return []tea.Msg{msg}
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+// send updates the model with the message and then with the messages of the resulting commands.
+// It returns the messages that are not fed back, such as tea.QuitMsg.
+func send(t *testing.T, m model, msgs ...tea.Msg) (model, []tea.Msg) {
+	t.Helper()
+	var rest []tea.Msg
+	for len(msgs) > 0 {
+		msg := msgs[0]
+		msgs = msgs[1:]
+		if _, ok := msg.(tea.QuitMsg); ok {
+			rest = append(rest, msg)
+			continue
+		}
+		next, cmd := m.Update(msg)
+		m = next.(model)
+		msgs = append(msgs, run(cmd)...)
+	}
+	return m, rest
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+func expectList(r *mockRuntime, clusters []*v1alpha1.Cluster, operations []*v1alpha1.LongRunningOperation) {
+	r.clusters.EXPECT().ListClusters(gomock.Any(), connect.NewRequest(&v1alpha1.ListClustersRequest{
+		Parent: "projects/" + testProject,
+	})).Return(connect.NewResponse(&v1alpha1.ListClustersResponse{Clusters: clusters}), nil)
+	r.operations.EXPECT().ListOperations(gomock.Any(), connect.NewRequest(&v1alpha1.ListOperationsRequest{
+		Parent: "projects/" + testProject,
+	})).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{Operations: operations}), nil)
+}
+
+func assertView(t *testing.T, m model, want ...string) {
+	t.Helper()
+	view := m.View()
+	for _, w := range want {
+		if !strings.Contains(view, w) {
+			t.Errorf("View() does not contain %q:\n%s", w, view)
+		}
+	}
+}
+
+func TestModel_list(t *testing.T) {
+	m, r := newTestModel(t)
+	op := testOperation(t, "cluster-create-123", "Running", false, "started", "provisioning")
+	expectList(r, testClusters, []*v1alpha1.LongRunningOperation{op})
+
+	m, _ = send(t, m, run(m.Init())...)
+	assertView(t, m, "Clusters (2)", "Operations (1)", "> c1     dev", "  c2     prod")
+
+	m, _ = send(t, m, key("j"))
+	assertView(t, m, "  c1     dev", "> c2     prod")
+
+	m, _ = send(t, m, key("tab"))
+	assertView(t, m, "> cluster-create-123   false   Running   provisioning")
+
+	_, rest := send(t, m, key("q"))
+	if len(rest) != 1 {
+		t.Errorf("q did not quit: %v", rest)
+	}
+}
+
+func TestModel_refreshError(t *testing.T) {
+	m, r := newTestModel(t)
+	r.clusters.EXPECT().ListClusters(gomock.Any(), gomock.Any()).Return(nil, connect.NewError(connect.CodeUnavailable, errors.New("down")))
+	r.operations.EXPECT().ListOperations(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{}), nil)
+
+	m, _ = send(t, m, m.listClusters())
+	assertView(t, m, "failed to list clusters: unavailable: down")
+
+	// A successful refresh clears the error
+	m, _ = send(t, m, m.listOperations())
+	if strings.Contains(m.View(), "failed") {
+		t.Errorf("View() still shows the error:\n%s", m.View())
+	}
+}
+
+func TestModel_detail(t *testing.T) {
+	m, r := newTestModel(t)
+	op := testOperation(t, "cluster-create-123", "Running", false, "started")
+	updated := testOperation(t, "cluster-create-123", "Succeeded", true, "started", "cluster is ready")
+	expectList(r, nil, []*v1alpha1.LongRunningOperation{op})
+	r.operations.EXPECT().GetOperation(gomock.Any(), connect.NewRequest(&v1alpha1.GetOperationRequest{
+		Name: op.GetName(),
+	})).Return(connect.NewResponse(updated), nil)
+
+	m, _ = send(t, m, run(m.Init())...)
+	m, _ = send(t, m, key("tab"), key("enter"))
+	at := formatTime(testTime)
+	assertView(t, m,
+		"Operation projects/test-project/operations/cluster-create-123 (done)",
+		"Phase:     Succeeded",
+		"Cluster:   c3 staging",
+		"Namespace: project-test-project",
+		"  "+at+"  started\n  "+at+"  cluster is ready",
+	)
+
+	m, _ = send(t, m, key("esc"))
+	assertView(t, m, "> cluster-create-123")
+}
+
+func TestModel_create(t *testing.T) {
+	m, r := newTestModel(t)
+	op := testOperation(t, "cluster-create-456", "Pending", false)
+	r.clusters.EXPECT().CreateCluster(gomock.Any(), connect.NewRequest(&v1alpha1.CreateClusterRequest{
+		Parent: "projects/" + testProject,
+		Cluster: &v1alpha1.Cluster{
+			DisplayName: "staging",
+			Description: "for tests",
+		},
+	})).Return(connect.NewResponse(op), nil)
+	expectList(r, testClusters, []*v1alpha1.LongRunningOperation{op})
+	r.operations.EXPECT().GetOperation(gomock.Any(), gomock.Any()).Return(connect.NewResponse(op), nil)
+
+	m, _ = send(t, m, key("c"))
+	assertView(t, m, "Create a cluster", "Display name:", "Description:")
+
+	m, _ = send(t, m, key("staging"), key("enter"), key("for tests"), key("enter"))
+	assertView(t, m,
+		"started to create cluster",
+		"Operation projects/test-project/operations/cluster-create-456 (running)",
+		"Phase:     Pending",
+	)
+
+	m, _ = send(t, m, key("esc"))
+	assertView(t, m, "> cluster-create-456")
+}
+
+func TestModel_delete(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		mock   func(*mockRuntime, *v1alpha1.LongRunningOperation)
+		want   []string
+	}{
+		{
+			name:   "delete the selected cluster",
+			answer: "y",
+			mock: func(r *mockRuntime, op *v1alpha1.LongRunningOperation) {
+				r.clusters.EXPECT().DeleteCluster(gomock.Any(), connect.NewRequest(&v1alpha1.DeleteClusterRequest{
+					Name: "projects/test-project/clusters/c2",
+				})).Return(connect.NewResponse(op), nil)
+				expectList(r, testClusters, nil)
+				r.operations.EXPECT().GetOperation(gomock.Any(), gomock.Any()).Return(connect.NewResponse(op), nil)
+			},
+			want: []string{"started to delete cluster c2", "cluster-delete-789 (running)"},
+		},
+		{
+			name:   "cancel",
+			answer: "n",
+			want:   []string{"canceled", "> c2     prod"},
+		},
+		{
+			name:   "failed to delete",
+			answer: "y",
+			mock: func(r *mockRuntime, _ *v1alpha1.LongRunningOperation) {
+				r.clusters.EXPECT().DeleteCluster(gomock.Any(), gomock.Any()).Return(nil, connect.NewError(connect.CodePermissionDenied, errors.New("denied")))
+			},
+			want: []string{"failed to delete cluster c2: permission_denied: denied", "> c2     prod"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, r := newTestModel(t)
+			if tt.mock != nil {
+				tt.mock(r, testOperation(t, "cluster-delete-789", "Pending", false))
+			}
+			m, _ = send(t, m, clustersMsg{clusters: testClusters}, key("down"), key("d"))
+			assertView(t, m, "Delete cluster c2 (prod)? [y/N]")
+
+			m, _ = send(t, m, key(tt.answer))
+			assertView(t, m, tt.want...)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package dashboard
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Fields of the form to create a cluster.
+const (
+	fieldDisplayName = iota
+	fieldDescription
+)
+
+// form is the form to create a cluster, moving the focus from one field to the next.
+type form struct {
+	inputs  []textinput.Model
+	focused int
+}
+
+func newForm() form {
+	displayName := textinput.New()
+	displayName.Prompt = "Display name: "
+	displayName.CharLimit = 128
+	description := textinput.New()
+	description.Prompt = "Description:  "
+	description.CharLimit = 1024
+	return form{inputs: []textinput.Model{displayName, description}}
+}
+
+func (f form) value(field int) string {
+	return strings.TrimSpace(f.inputs[field].Value())
+}
+
+// last returns true if the focus is on the last field, which submits the form on enter.
+func (f form) last() bool {
+	return f.focused == len(f.inputs)-1
+}
+
+func (f *form) focus() tea.Cmd {
+	for i := range f.inputs {
+		f.inputs[i].Blur()
+	}
+	return f.inputs[f.focused].Focus()
+}
+
+func (f *form) next() tea.Cmd {
+	f.focused = min(f.focused+1, len(f.inputs)-1)
+	return f.focus()
+}
+
+func (f *form) prev() tea.Cmd {
+	f.focused = max(f.focused-1, 0)
+	return f.focus()
+}
+
+func (f form) update(msg tea.Msg) (form, tea.Cmd) {
+	var cmd tea.Cmd
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return f, cmd
+}
+
+func (f form) view() string {
+	var b strings.Builder
+	for _, input := range f.inputs {
+		b.WriteString(input.View())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,348 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"connectrpc.com/connect"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/cluster"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+)
+
+// screen is what the dashboard shows.
+type screen int
+
+const (
+	screenClusters screen = iota
+	screenOperations
+	// screenDetail shows the pipeline of the selected operation.
+	screenDetail
+	screenCreate
+	// screenDelete asks for the confirmation to delete the selected cluster.
+	screenDelete
+)
+
+type (
+	tickMsg     struct{}
+	clustersMsg struct {
+		clusters []*v1alpha1.Cluster
+		err      error
+	}
+	operationsMsg struct {
+		operations []*v1alpha1.LongRunningOperation
+		err        error
+	}
+	// operationMsg is the operation shown in screenDetail.
+	operationMsg struct {
+		operation *v1alpha1.LongRunningOperation
+		err       error
+	}
+	// mutatedMsg is the result of a create or delete of a cluster.
+	mutatedMsg struct {
+		action    string
+		operation *v1alpha1.LongRunningOperation
+		err       error
+	}
+)
+
+type model struct {
+	ctx      context.Context
+	r        runtime
+	project  string
+	interval time.Duration
+
+	screen screen
+	// tab is screenClusters or screenOperations, which the other screens return to.
+	tab             screen
+	clusters        []*v1alpha1.Cluster
+	operations      []*v1alpha1.LongRunningOperation
+	clusterCursor   int
+	operationCursor int
+	detail          *v1alpha1.LongRunningOperation
+	form            form
+	// status is the result of the last action, such as a failure to create a cluster.
+	status string
+	// err is the error of the last refresh, cleared by the next successful one.
+	err   error
+	width int
+}
+
+func newModel(ctx context.Context, r runtime, project string, interval time.Duration) model {
+	return model{
+		ctx:      ctx,
+		r:        r,
+		project:  project,
+		interval: interval,
+		screen:   screenClusters,
+		tab:      screenClusters,
+	}
+}
+
+func (m model) parent() string {
+	return "projects/" + m.project
+}
+
+func (m model) Init() tea.Cmd {
+	return tea.Batch(m.refresh(), m.tick())
+}
+
+// tick schedules the next refresh, or nothing if the interval is 0.
+func (m model) tick() tea.Cmd {
+	if m.interval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.interval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// refresh retrieves the clusters and operations, and the operation in screenDetail if any.
+func (m model) refresh() tea.Cmd {
+	cmds := []tea.Cmd{m.listClusters, m.listOperations}
+	if m.screen == screenDetail && m.detail.GetName() != "" {
+		cmds = append(cmds, m.getOperation(m.detail.GetName()))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m model) listClusters() tea.Msg {
+	var clusters []*v1alpha1.Cluster
+	err := cluster.ListPages(m.ctx, m.r.ClusterService(), &v1alpha1.ListClustersRequest{
+		Parent: m.parent(),
+	}, func(res *v1alpha1.ListClustersResponse) error {
+		clusters = append(clusters, res.GetClusters()...)
+		return nil
+	})
+	if err != nil {
+		return clustersMsg{err: err}
+	}
+	return clustersMsg{clusters: clusters}
+}
+
+// listOperations retrieves the first page of the newest operations rather than all of them on every refresh.
+func (m model) listOperations() tea.Msg {
+	res, err := m.r.LongRunningOperationService().ListOperations(m.ctx, connect.NewRequest(&v1alpha1.ListOperationsRequest{
+		Parent: m.parent(),
+	}))
+	if err != nil {
+		return operationsMsg{err: fmt.Errorf("failed to list operations: %w", err)}
+	}
+	return operationsMsg{operations: res.Msg.GetOperations()}
+}
+
+func (m model) getOperation(name string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := m.r.LongRunningOperationService().GetOperation(m.ctx, connect.NewRequest(&v1alpha1.GetOperationRequest{
+			Name: name,
+		}))
+		if err != nil {
+			return operationMsg{err: fmt.Errorf("failed to get operation: %w", err)}
+		}
+		return operationMsg{operation: res.Msg}
+	}
+}
+
+func (m model) createCluster(displayName, description string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := m.r.ClusterService().CreateCluster(m.ctx, connect.NewRequest(&v1alpha1.CreateClusterRequest{
+			Parent: m.parent(),
+			Cluster: &v1alpha1.Cluster{
+				DisplayName: displayName,
+				Description: description,
+			},
+		}))
+		if err != nil {
+			return mutatedMsg{action: "create cluster", err: err}
+		}
+		return mutatedMsg{action: "create cluster", operation: res.Msg}
+	}
+}
+
+func (m model) deleteCluster(name string) tea.Cmd {
+	return func() tea.Msg {
+		res, err := m.r.ClusterService().DeleteCluster(m.ctx, connect.NewRequest(&v1alpha1.DeleteClusterRequest{
+			Name: name,
+		}))
+		if err != nil {
+			return mutatedMsg{action: "delete cluster " + path.Base(name), err: err}
+		}
+		return mutatedMsg{action: "delete cluster " + path.Base(name), operation: res.Msg}
+	}
+}
+
+func (m model) selectedCluster() *v1alpha1.Cluster {
+	if m.clusterCursor < len(m.clusters) {
+		return m.clusters[m.clusterCursor]
+	}
+	return nil
+}
+
+func (m model) selectedOperation() *v1alpha1.LongRunningOperation {
+	if m.operationCursor < len(m.operations) {
+		return m.operations[m.operationCursor]
+	}
+	return nil
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+	case tickMsg:
+		return m, tea.Batch(m.refresh(), m.tick())
+	case clustersMsg:
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.clusters = msg.clusters
+		m.clusterCursor = min(m.clusterCursor, max(len(m.clusters)-1, 0))
+		return m, nil
+	case operationsMsg:
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.operations = msg.operations
+		m.operationCursor = min(m.operationCursor, max(len(m.operations)-1, 0))
+		return m, nil
+	case operationMsg:
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		if m.screen == screenDetail && msg.operation.GetName() == m.detail.GetName() {
+			m.detail = msg.operation
+		}
+		return m, nil
+	case mutatedMsg:
+		return m.mutated(msg)
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.screen {
+		case screenCreate:
+			return m.updateCreate(msg)
+		case screenDelete:
+			return m.updateDelete(msg)
+		case screenDetail:
+			return m.updateDetail(msg)
+		default:
+			return m.updateList(msg)
+		}
+	}
+	if m.screen == screenCreate {
+		var cmd tea.Cmd
+		m.form, cmd = m.form.update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// mutated shows the operation of a create or delete in screenDetail to follow its progress.
+func (m model) mutated(msg mutatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = fmt.Sprintf("failed to %s: %v", msg.action, msg.err)
+		return m, nil
+	}
+	m.status = fmt.Sprintf("started to %s", msg.action)
+	m.tab = screenOperations
+	m.screen = screenDetail
+	m.detail = msg.operation
+	return m, m.refresh()
+}
+
+func (m model) updateList(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		return m, tea.Quit
+	case "tab":
+		if m.screen == screenClusters {
+			m.screen = screenOperations
+		} else {
+			m.screen = screenClusters
+		}
+		m.tab = m.screen
+	case "up", "k":
+		if m.screen == screenClusters {
+			m.clusterCursor = max(m.clusterCursor-1, 0)
+		} else {
+			m.operationCursor = max(m.operationCursor-1, 0)
+		}
+	case "down", "j":
+		if m.screen == screenClusters {
+			m.clusterCursor = min(m.clusterCursor+1, max(len(m.clusters)-1, 0))
+		} else {
+			m.operationCursor = min(m.operationCursor+1, max(len(m.operations)-1, 0))
+		}
+	case "r":
+		return m, m.refresh()
+	case "enter":
+		if op := m.selectedOperation(); m.screen == screenOperations && op != nil {
+			m.screen = screenDetail
+			m.detail = op
+			return m, m.getOperation(op.GetName())
+		}
+	case "c":
+		if m.screen == screenClusters {
+			m.screen = screenCreate
+			m.form = newForm()
+			return m, m.form.focus()
+		}
+	case "d":
+		if m.screen == screenClusters && m.selectedCluster() != nil {
+			m.screen = screenDelete
+		}
+	}
+	return m, nil
+}
+
+func (m model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc", "backspace":
+		m.screen = m.tab
+		m.detail = nil
+	case "r":
+		return m, m.refresh()
+	}
+	return m, nil
+}
+
+func (m model) updateCreate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.screen = m.tab
+		return m, nil
+	case "enter":
+		if m.form.last() {
+			m.screen = m.tab
+			return m, m.createCluster(m.form.value(fieldDisplayName), m.form.value(fieldDescription))
+		}
+		return m, m.form.next()
+	case "tab", "down":
+		return m, m.form.next()
+	case "shift+tab", "up":
+		return m, m.form.prev()
+	}
+	var cmd tea.Cmd
+	m.form, cmd = m.form.update(msg)
+	return m, cmd
+}
+
+func (m model) updateDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.screen = m.tab
+	switch msg.String() {
+	case "y", "Y":
+		return m, m.deleteCluster(m.selectedCluster().GetName())
+	default:
+		m.status = "canceled"
+		return m, nil
+	}
+}
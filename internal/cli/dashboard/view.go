@@ -0,0 +1,201 @@
+package dashboard
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	activeTab     = lipgloss.NewStyle().Bold(true).Underline(true)
+	inactiveTab   = lipgloss.NewStyle().Faint(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	warningStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+func (m model) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("kcli dashboard - project " + m.project))
+	b.WriteString("\n\n")
+	switch m.screen {
+	case screenClusters, screenOperations:
+		b.WriteString(m.viewTabs())
+		b.WriteString("\n\n")
+		if m.screen == screenClusters {
+			b.WriteString(m.viewClusters())
+		} else {
+			b.WriteString(m.viewOperations())
+		}
+	case screenDetail:
+		b.WriteString(viewDetail(m.detail))
+	case screenCreate:
+		b.WriteString("Create a cluster\n\n")
+		b.WriteString(m.form.view())
+	case screenDelete:
+		c := m.selectedCluster()
+		fmt.Fprintf(&b, "Delete cluster %s (%s)? [y/N]\n", path.Base(c.GetName()), c.GetDisplayName())
+	}
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString(m.status)
+		b.WriteString("\n")
+	}
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(m.err.Error()))
+		b.WriteString("\n")
+	}
+	b.WriteString(helpStyle.Render(m.help()))
+	b.WriteString("\n")
+	return b.String()
+}
+
+func (m model) help() string {
+	switch m.screen {
+	case screenClusters:
+		return "tab: operations - up/down: select - c: create - d: delete - r: refresh - q: quit"
+	case screenOperations:
+		return "tab: clusters - up/down: select - enter: pipeline - r: refresh - q: quit"
+	case screenDetail:
+		return "esc: back - r: refresh - q: quit"
+	case screenCreate:
+		return "tab: next field - enter: next field or create - esc: cancel"
+	default:
+		return "y: delete - any other key: cancel"
+	}
+}
+
+func (m model) viewTabs() string {
+	clusters, operations := inactiveTab, inactiveTab
+	if m.screen == screenClusters {
+		clusters = activeTab
+	} else {
+		operations = activeTab
+	}
+	return clusters.Render(fmt.Sprintf("Clusters (%d)", len(m.clusters))) + "   " +
+		operations.Render(fmt.Sprintf("Operations (%d)", len(m.operations)))
+}
+
+func (m model) viewClusters() string {
+	if len(m.clusters) == 0 {
+		return "No clusters.\n"
+	}
+	rows := [][]string{{"NAME", "DISPLAY NAME", "DESCRIPTION"}}
+	for _, c := range m.clusters {
+		rows = append(rows, []string{path.Base(c.GetName()), c.GetDisplayName(), c.GetDescription()})
+	}
+	return viewTable(rows, m.clusterCursor)
+}
+
+func (m model) viewOperations() string {
+	if len(m.operations) == 0 {
+		return "No operations.\n"
+	}
+	rows := [][]string{{"NAME", "DONE", "PHASE", "MESSAGE"}}
+	for _, op := range m.operations {
+		var message string
+		if c := lastCondition(op); c != nil {
+			message = c.GetMessage()
+		}
+		rows = append(rows, []string{path.Base(op.GetName()), fmt.Sprint(op.GetDone()), logrunningoperation.Phase(op), message})
+	}
+	return viewTable(rows, m.operationCursor)
+}
+
+// viewTable aligns the rows with a header, and highlights the selected row after the header.
+func viewTable(rows [][]string, selected int) string {
+	var buf strings.Builder
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	var b strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if i == selected+1 {
+			b.WriteString(selectedStyle.Render("> " + line))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// pipeline returns the pipeline of the operation, or nil if it has none.
+func pipeline(op *v1alpha1.LongRunningOperation) *v1alpha1.LongRunningOperation_Pipeline {
+	var p v1alpha1.LongRunningOperation_Pipeline
+	if op.GetMetadata() == nil || op.GetMetadata().UnmarshalTo(&p) != nil {
+		return nil
+	}
+	return &p
+}
+
+func lastCondition(op *v1alpha1.LongRunningOperation) *v1alpha1.LongRunningOperation_Pipeline_Status_Condition {
+	conditions := pipeline(op).GetStatus().GetConditions()
+	if len(conditions) == 0 {
+		return nil
+	}
+	return conditions[len(conditions)-1]
+}
+
+func formatTime(t *timestamppb.Timestamp) string {
+	if t == nil {
+		return "-"
+	}
+	return t.AsTime().Local().Format(time.DateTime)
+}
+
+// viewDetail shows the pipeline of the operation: the cluster it runs for, the timeline of its conditions and its events.
+func viewDetail(op *v1alpha1.LongRunningOperation) string {
+	var b strings.Builder
+	state := "running"
+	if op.GetDone() {
+		state = "done"
+	}
+	fmt.Fprintf(&b, "Operation %s (%s)\n", op.GetName(), state)
+	p := pipeline(op)
+	if p == nil {
+		b.WriteString("No pipeline yet.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Phase:     %s\n", p.GetStatus().GetPhase())
+	if spec := p.GetSpec(); spec != nil {
+		fmt.Fprintf(&b, "Cluster:   %s %s\n", spec.GetName(), spec.GetDisplayName())
+	}
+	if p.GetNamespace() != "" {
+		fmt.Fprintf(&b, "Namespace: %s\n", p.GetNamespace())
+	}
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("Conditions"))
+	b.WriteString("\n")
+	if len(p.GetStatus().GetConditions()) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, c := range p.GetStatus().GetConditions() {
+		fmt.Fprintf(&b, "  %s  %s\n", formatTime(c.GetLastTransitionTime()), c.GetMessage())
+	}
+	if len(p.GetEvents()) > 0 {
+		b.WriteString("\n")
+		b.WriteString(titleStyle.Render("Events"))
+		b.WriteString("\n")
+		for _, e := range p.GetEvents() {
+			line := fmt.Sprintf("  %s  %-7s  %s: %s (x%d)", formatTime(e.GetLastTimestamp()), e.GetType(), e.GetReason(), e.GetMessage(), e.GetCount())
+			if e.GetType() == "Warning" {
+				line = warningStyle.Render(line)
+			}
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
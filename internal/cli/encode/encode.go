@@ -3,6 +3,7 @@ package encode
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"buf.build/go/protoyaml"
 	"github.com/spf13/cobra"
@@ -12,46 +13,124 @@ import (
 )
 
 // Encoder defines an interface for encoding protobuf messages into different formats.
-// It supports JSON, YAML, and text formats.
+// It supports JSON, YAML and text encodings, table and wide tables, NDJSON,
+// and kubectl style jsonpath=TEMPLATE and go-template=TEMPLATE formats.
 //
 // It implements pflag.Value interface to allow it to be used as a flag value in Cobra commands.
 type Encoder string
 
 const (
-	json Encoder = "json"
-	yaml Encoder = "yaml"
-	text Encoder = "text"
+	json   Encoder = "json"
+	yaml   Encoder = "yaml"
+	text   Encoder = "text"
+	table  Encoder = "table"
+	wide   Encoder = "wide"
+	ndjson Encoder = "ndjson"
+	// jsonPath and goTemplate take the template after "=", such as jsonpath={.name}.
+	jsonPath   Encoder = "jsonpath"
+	goTemplate Encoder = "go-template"
 )
 
-func suppressError(cmd *cobra.Command, err error) {
-	// no-op for now, can be used to handle errors gracefully
+// completions are the completions of the output flag. The template formats are completed up to "=".
+var completions = []cobra.Completion{
+	cobra.CompletionWithDesc(string(yaml), "YAML (default)"),
+	cobra.CompletionWithDesc(string(json), "JSON"),
+	cobra.CompletionWithDesc(string(text), "Protobuf text format"),
+	cobra.CompletionWithDesc(string(table), "Table of the main fields"),
+	cobra.CompletionWithDesc(string(wide), "Table of all the fields"),
+	cobra.CompletionWithDesc(string(ndjson), "A line of JSON per element of a list"),
+	cobra.CompletionWithDesc(string(jsonPath)+"=", "kubectl style JSONPath template"),
+	cobra.CompletionWithDesc(string(goTemplate)+"=", "Go template"),
 }
 
 // VarP registers the Encoder as a flag with the given command.
 func (e *Encoder) VarP(cmd *cobra.Command) {
-	cmd.Flags().VarP(e, "output", "o", "Output format (json, yaml, text)")
+	cmd.Flags().VarP(e, "output", "o", "Output format (json, yaml, text, table, wide, ndjson, jsonpath=TEMPLATE, go-template=TEMPLATE)")
+	_ = cmd.RegisterFlagCompletionFunc("output", complete)
+}
+
+func complete(_ *cobra.Command, _ []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	var matches []cobra.Completion
+	directive := cobra.ShellCompDirectiveNoFileComp
+	for _, c := range completions {
+		value, _, _ := strings.Cut(c, "\t")
+		if !strings.HasPrefix(value, toComplete) {
+			continue
+		}
+		matches = append(matches, c)
+		if strings.HasSuffix(value, "=") {
+			// No space after "jsonpath=" so that the template can be typed right away
+			directive |= cobra.ShellCompDirectiveNoSpace
+		}
+	}
+	return matches, directive
 }
 
 // Print encodes the given protobuf message and writes it to the command's output.
-func (e *Encoder) Print(cmd *cobra.Command, v proto.Message) {
-	e.Encode(cmd.OutOrStdout(), v)
+func (e *Encoder) Print(cmd *cobra.Command, v proto.Message) error {
+	return e.Encode(cmd.OutOrStdout(), v)
+}
+
+// Separator returns the separator to write between the messages printed one after another.
+// YAML documents are separated by "---", and the other formats by a newline.
+func (e *Encoder) Separator() string {
+	switch e.format() {
+	case json, text:
+		return "\n"
+	case ndjson, table, wide, jsonPath, goTemplate:
+		return ""
+	default:
+		return "---\n"
+	}
+}
+
+// Streaming returns true if the format renders each element of a list response independently,
+// so that a list command can print every page as soon as it is retrieved.
+func (e *Encoder) Streaming() bool {
+	return e.format() == ndjson
+}
+
+// format returns the format without the template argument. YAML is the default format.
+func (e *Encoder) format() Encoder {
+	format, _, _ := strings.Cut(string(*e), "=")
+	if format == "" {
+		return yaml
+	}
+	return Encoder(format)
 }
 
 // Encode encodes the given protobuf message into the specified format and writes it to the provided writer.
-// It supports JSON, YAML, and text formats based on the value of the Encoder.
-func (e *Encoder) Encode(w io.Writer, v proto.Message) {
+// It supports the formats listed in Encoder based on the value of the Encoder.
+// Nothing is written if the message cannot be encoded, such as a template referring to a missing field.
+func (e *Encoder) Encode(w io.Writer, v proto.Message) error {
 	var bytes []byte
 	var err error
-	switch *e {
+	_, arg, _ := strings.Cut(string(*e), "=")
+	switch e.format() {
 	case json:
-		bytes, err = protojson.Marshal(v)
+		bytes, err = protojson.MarshalOptions{Resolver: resolver}.Marshal(v)
 	case text:
-		bytes, err = prototext.Marshal(v)
+		bytes, err = prototext.MarshalOptions{Resolver: resolver}.Marshal(v)
+	case table:
+		bytes, err = encodeTable(v, false)
+	case wide:
+		bytes, err = encodeTable(v, true)
+	case ndjson:
+		bytes, err = encodeNDJSON(v)
+	case jsonPath:
+		bytes, err = encodeJSONPath(v, arg)
+	case goTemplate:
+		bytes, err = encodeGoTemplate(v, arg)
 	default:
-		bytes, err = protoyaml.Marshal(v)
+		bytes, err = protoyaml.MarshalOptions{Resolver: resolver}.Marshal(v)
 	}
-	suppressError(nil, err)
-	w.Write(bytes)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s as %s: %w", v.ProtoReflect().Descriptor().Name(), e.format(), err)
+	}
+	if _, err := w.Write(bytes); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
 }
 
 func (e *Encoder) String() string {
@@ -59,16 +138,24 @@ func (e *Encoder) String() string {
 }
 
 func (e *Encoder) Set(v string) error {
-	switch v {
-	case "json":
-		*e = json
-	case "yaml":
-		*e = yaml
-	case "text":
-		*e = text
+	format, arg, hasArg := strings.Cut(v, "=")
+	switch Encoder(format) {
+	case json, yaml, text, table, wide, ndjson:
+		if hasArg {
+			return fmt.Errorf("output format %s does not take a template", format)
+		}
+	case jsonPath:
+		if _, err := parseJSONPath(arg); err != nil {
+			return err
+		}
+	case goTemplate:
+		if _, err := parseGoTemplate(arg); err != nil {
+			return err
+		}
 	default:
-		return fmt.Errorf("unknown output format: %s, must be one of json, yaml, text", v)
+		return fmt.Errorf("unknown output format: %s, must be one of json, yaml, text, table, wide, ndjson, jsonpath=TEMPLATE, go-template=TEMPLATE", v)
 	}
+	*e = Encoder(v)
 	return nil
 }
 
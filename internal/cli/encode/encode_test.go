@@ -2,12 +2,16 @@ package encode
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestEncoder_Encode(t *testing.T) {
@@ -42,11 +46,117 @@ func TestEncoder_Encode(t *testing.T) {
 			},
 			want: `name:"operation-1234"`,
 		},
+		{
+			name:    "encode to table",
+			encoder: table,
+			message: &v1alpha1.Cluster{
+				Name:        "projects/p1/clusters/c1",
+				DisplayName: "dev",
+			},
+			want: "NAME   DISPLAY NAME\nc1     dev\n",
+		},
+		{
+			name:    "encode an empty response to table",
+			encoder: table,
+			message: &emptypb.Empty{},
+			want:    "RESULT\ndone\n",
+		},
+		{
+			name:    "encode a list to table",
+			encoder: table,
+			message: &v1alpha1.ListClustersResponse{
+				Clusters: []*v1alpha1.Cluster{
+					{Name: "projects/p1/clusters/c1", DisplayName: "dev"},
+					{Name: "projects/p1/clusters/cluster-2"},
+				},
+			},
+			want: "NAME        DISPLAY NAME\nc1          dev\ncluster-2   <none>\n",
+		},
+		{
+			name:    "encode to wide table",
+			encoder: wide,
+			message: &v1alpha1.ListOperationsResponse{
+				Operations: []*v1alpha1.LongRunningOperation{
+					{Name: "projects/p1/operations/o1", Done: true},
+				},
+			},
+			want: "NAME   DONE   PHASE    MESSAGE   RESPONSE   RESOURCE NAME\no1     true   <none>   <none>    <none>     projects/p1/operations/o1\n",
+		},
+		{
+			name:    "encode an operation with metadata to YAML",
+			encoder: yaml,
+			message: operation(t),
+			want: `name: projects/p1/operations/o1
+done: true
+metadata:
+    '@type': type.googleapis.com/api.proto.v1alpha1.LongRunningOperation.Pipeline
+    status:
+        phase: Succeeded
+        conditions:
+            - message: started
+            - message: cluster is ready
+response:
+    '@type': type.googleapis.com/api.proto.v1alpha1.Cluster
+    name: projects/p1/clusters/c1
+`,
+		},
+		{
+			name:    "encode an operation with metadata with jsonpath",
+			encoder: "jsonpath={.metadata.status.phase} {.response.name}",
+			message: operation(t),
+			want:    "Succeeded projects/p1/clusters/c1",
+		},
+		{
+			name:    "encode an operation with metadata to table",
+			encoder: table,
+			message: operation(t),
+			want:    "NAME   DONE   PHASE       MESSAGE\no1     true   Succeeded   cluster is ready\n",
+		},
+		{
+			name:    "encode an operation with metadata to wide table",
+			encoder: wide,
+			message: operation(t),
+			want:    "NAME   DONE   PHASE       MESSAGE            RESPONSE   RESOURCE NAME\no1     true   Succeeded   cluster is ready   Cluster    projects/p1/operations/o1\n",
+		},
+		{
+			name:    "encode a list to NDJSON",
+			encoder: ndjson,
+			message: &v1alpha1.ListClustersResponse{
+				Clusters: []*v1alpha1.Cluster{
+					{Name: "projects/p1/clusters/c1"},
+					{Name: "projects/p1/clusters/c2"},
+				},
+			},
+			want: "{\"name\":\"projects/p1/clusters/c1\"}\n{\"name\":\"projects/p1/clusters/c2\"}\n",
+		},
+		{
+			name:    "encode with jsonpath",
+			encoder: "jsonpath={.clusters[*].displayName}",
+			message: &v1alpha1.ListClustersResponse{
+				Clusters: []*v1alpha1.Cluster{
+					{DisplayName: "dev"},
+					{DisplayName: "prod"},
+				},
+			},
+			want: "dev prod",
+		},
+		{
+			name:    "encode with go-template",
+			encoder: "go-template={{range .clusters}}{{.name}}{{\"\\n\"}}{{end}}",
+			message: &v1alpha1.ListClustersResponse{
+				Clusters: []*v1alpha1.Cluster{
+					{Name: "projects/p1/clusters/c1"},
+				},
+			},
+			want: "projects/p1/clusters/c1\n",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var got bytes.Buffer
-			tt.encoder.Encode(&got, tt.message)
+			if err := tt.encoder.Encode(&got, tt.message); err != nil {
+				t.Fatalf("Encoder.Encode() error = %v", err)
+			}
 			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
 				t.Errorf("Encoder.Encode() mismatch (-want +got):\n%s", diff)
 			}
@@ -54,6 +164,74 @@ func TestEncoder_Encode(t *testing.T) {
 	}
 }
 
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestEncoder_Encode_error(t *testing.T) {
+	type testcase struct {
+		name    string
+		encoder Encoder
+		w       io.Writer
+	}
+	tests := []testcase{
+		{
+			name:    "jsonpath refers to a missing field",
+			encoder: "jsonpath={.missing}",
+			w:       &bytes.Buffer{},
+		},
+		{
+			name:    "go-template fails to execute",
+			encoder: "go-template={{.name.missing}}",
+			w:       &bytes.Buffer{},
+		},
+		{
+			name:    "write fails",
+			encoder: json,
+			w:       failingWriter{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.encoder.Encode(tt.w, &v1alpha1.Cluster{Name: "projects/p1/clusters/c1"})
+			if err == nil {
+				t.Fatal("Encoder.Encode() error = nil, want an error")
+			}
+			if buf, ok := tt.w.(*bytes.Buffer); ok && buf.Len() > 0 {
+				t.Errorf("Encoder.Encode() wrote %q on error, want nothing", buf.String())
+			}
+		})
+	}
+}
+
+func operation(t *testing.T) *v1alpha1.LongRunningOperation {
+	t.Helper()
+	metadata, err := anypb.New(&v1alpha1.LongRunningOperation_Pipeline{
+		Status: &v1alpha1.LongRunningOperation_Pipeline_Status{
+			Phase: "Succeeded",
+			Conditions: []*v1alpha1.LongRunningOperation_Pipeline_Status_Condition{
+				{Message: "started"},
+				{Message: "cluster is ready"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := anypb.New(&v1alpha1.Cluster{Name: "projects/p1/clusters/c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v1alpha1.LongRunningOperation{
+		Name:     "projects/p1/operations/o1",
+		Metadata: metadata,
+		Done:     true,
+		Response: response,
+	}
+}
+
 func TestEncoder_VarP(t *testing.T) {
 	type testcase struct {
 		name string
@@ -76,6 +254,16 @@ func TestEncoder_VarP(t *testing.T) {
 			args: []string{"--output", "text"},
 			want: text,
 		},
+		{
+			name: "set to wide",
+			args: []string{"--output", "wide"},
+			want: wide,
+		},
+		{
+			name: "set to jsonpath",
+			args: []string{"--output", "jsonpath={.name}"},
+			want: "jsonpath={.name}",
+		},
 		{
 			name: "set to JSON with alias",
 			args: []string{"-o", "json"},
@@ -97,3 +285,45 @@ func TestEncoder_VarP(t *testing.T) {
 		})
 	}
 }
+
+func TestEncoder_Set(t *testing.T) {
+	for _, v := range []string{"xml", "json=x", "jsonpath={.name", "go-template={{.name"} {
+		var encoder Encoder
+		if err := encoder.Set(v); err == nil {
+			t.Errorf("Set(%q) error = nil, want an error", v)
+		}
+	}
+}
+
+func TestEncoder_complete(t *testing.T) {
+	tests := []struct {
+		toComplete    string
+		want          []string
+		wantDirective cobra.ShellCompDirective
+	}{
+		{
+			toComplete:    "ya",
+			want:          []string{"yaml\tYAML (default)"},
+			wantDirective: cobra.ShellCompDirectiveNoFileComp,
+		},
+		{
+			toComplete: "js",
+			want: []string{
+				"json\tJSON",
+				"jsonpath=\tkubectl style JSONPath template",
+			},
+			wantDirective: cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.toComplete, func(t *testing.T) {
+			got, directive := complete(nil, nil, tt.toComplete)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("complete() mismatch (-want +got):\n%s", diff)
+			}
+			if directive != tt.wantDirective {
+				t.Errorf("complete() directive = %v, want %v", directive, tt.wantDirective)
+			}
+		})
+	}
+}
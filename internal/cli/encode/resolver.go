@@ -0,0 +1,41 @@
+package encode
+
+import (
+	"fmt"
+
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// resolver resolves the messages packed in the Any fields of the API messages, such as the metadata and
+// the response of a LongRunningOperation, so that every format renders them inline.
+var resolver = newResolver(
+	&v1alpha1.Cluster{},
+	&v1alpha1.LongRunningOperation_Pipeline{},
+	&emptypb.Empty{},
+)
+
+func newResolver(msgs ...proto.Message) *protoregistry.Types {
+	var types protoregistry.Types
+	for _, m := range msgs {
+		if err := types.RegisterMessage(m.ProtoReflect().Type()); err != nil {
+			panic(fmt.Sprintf("failed to register %T: %v", m, err))
+		}
+	}
+	return &types
+}
+
+// unpack returns the message packed in the Any, or nil if it is empty or of an unknown type.
+func unpack(a *anypb.Any) proto.Message {
+	if a == nil {
+		return nil
+	}
+	m, err := anypb.UnmarshalNew(a, proto.UnmarshalOptions{Resolver: resolver})
+	if err != nil {
+		return nil
+	}
+	return m
+}
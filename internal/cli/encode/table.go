@@ -0,0 +1,150 @@
+package encode
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// column is a column of the table output of a message.
+type column struct {
+	header string
+	// wide columns are only shown in the wide output.
+	wide  bool
+	value func(proto.Message) string
+}
+
+// columns are the table columns of the messages that can be printed as a table.
+// List responses are printed as a row per element.
+var columns = map[protoreflect.FullName][]column{
+	// Empty is the response of a done operation without a resource, such as a deleted cluster
+	fullName(&emptypb.Empty{}): {
+		{header: "RESULT", value: func(proto.Message) string { return "done" }},
+	},
+	fullName(&v1alpha1.Cluster{}): {
+		{header: "NAME", value: func(m proto.Message) string { return id(m.(*v1alpha1.Cluster).GetName()) }},
+		{header: "DISPLAY NAME", value: func(m proto.Message) string { return m.(*v1alpha1.Cluster).GetDisplayName() }},
+		{header: "DESCRIPTION", wide: true, value: func(m proto.Message) string { return m.(*v1alpha1.Cluster).GetDescription() }},
+		{header: "RESOURCE NAME", wide: true, value: func(m proto.Message) string { return m.(*v1alpha1.Cluster).GetName() }},
+	},
+	fullName(&v1alpha1.LongRunningOperation{}): {
+		{header: "NAME", value: func(m proto.Message) string { return id(m.(*v1alpha1.LongRunningOperation).GetName()) }},
+		{header: "DONE", value: func(m proto.Message) string {
+			return strconv.FormatBool(m.(*v1alpha1.LongRunningOperation).GetDone())
+		}},
+		{header: "PHASE", value: func(m proto.Message) string {
+			return pipeline(m.(*v1alpha1.LongRunningOperation)).GetStatus().GetPhase()
+		}},
+		{header: "MESSAGE", value: func(m proto.Message) string {
+			conditions := pipeline(m.(*v1alpha1.LongRunningOperation)).GetStatus().GetConditions()
+			if len(conditions) == 0 {
+				return ""
+			}
+			return conditions[len(conditions)-1].GetMessage()
+		}},
+		{header: "RESPONSE", wide: true, value: func(m proto.Message) string {
+			if res := unpack(m.(*v1alpha1.LongRunningOperation).GetResponse()); res != nil {
+				return string(res.ProtoReflect().Descriptor().Name())
+			}
+			return ""
+		}},
+		{header: "RESOURCE NAME", wide: true, value: func(m proto.Message) string {
+			return m.(*v1alpha1.LongRunningOperation).GetName()
+		}},
+	},
+}
+
+func fullName(m proto.Message) protoreflect.FullName {
+	return m.ProtoReflect().Descriptor().FullName()
+}
+
+// pipeline returns the pipeline metadata of the operation, or nil if it has none.
+func pipeline(op *v1alpha1.LongRunningOperation) *v1alpha1.LongRunningOperation_Pipeline {
+	p, _ := unpack(op.GetMetadata()).(*v1alpha1.LongRunningOperation_Pipeline)
+	return p
+}
+
+// id returns the last segment of a resource name, which is the ID of the resource.
+func id(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// items returns the elements of a list response such as ListClustersResponse, or nil if v is not a list response.
+// A list response is a message named List*Response with a repeated message field.
+func items(v proto.Message) ([]proto.Message, bool) {
+	m := v.ProtoReflect()
+	name := string(m.Descriptor().Name())
+	if !strings.HasPrefix(name, "List") || !strings.HasSuffix(name, "Response") {
+		return nil, false
+	}
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if !fd.IsList() || fd.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		list := m.Get(fd).List()
+		res := make([]proto.Message, 0, list.Len())
+		for j := range list.Len() {
+			res = append(res, list.Get(j).Message().Interface())
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+// encodeTable renders the message, or the elements of a list response, as a table with a row per message.
+func encodeTable(v proto.Message, wide bool) ([]byte, error) {
+	rows, ok := items(v)
+	var cols []column
+	if ok {
+		fields := v.ProtoReflect().Descriptor().Fields()
+		for i := range fields.Len() {
+			if fd := fields.Get(i); fd.IsList() && fd.Kind() == protoreflect.MessageKind {
+				cols = columns[fd.Message().FullName()]
+				break
+			}
+		}
+	} else {
+		rows = []proto.Message{v}
+		cols = columns[fullName(v)]
+	}
+	if cols == nil {
+		return nil, fmt.Errorf("table output is not supported for %s", fullName(v))
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	var headers []string
+	for _, c := range cols {
+		if c.wide && !wide {
+			continue
+		}
+		headers = append(headers, c.header)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	for _, row := range rows {
+		var values []string
+		for _, c := range cols {
+			if c.wide && !wide {
+				continue
+			}
+			value := c.value(row)
+			if value == "" {
+				value = "<none>"
+			}
+			values = append(values, value)
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package encode
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"text/template"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// object returns the message as the generic JSON object that the templates are evaluated on.
+// The field names are the same as the json output.
+func object(v proto.Message) (any, error) {
+	b, err := protojson.MarshalOptions{Resolver: resolver}.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var obj any
+	if err := stdjson.Unmarshal(b, &obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func parseJSONPath(tmpl string) (*jsonpath.JSONPath, error) {
+	j := jsonpath.New("output")
+	if err := j.Parse(tmpl); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath template %q: %w", tmpl, err)
+	}
+	return j, nil
+}
+
+func parseGoTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("output").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid go-template %q: %w", tmpl, err)
+	}
+	return t, nil
+}
+
+// encodeJSONPath renders the message with a kubectl style JSONPath template such as `{.name}`.
+func encodeJSONPath(v proto.Message, tmpl string) ([]byte, error) {
+	j, err := parseJSONPath(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := object(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := j.Execute(&buf, obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeGoTemplate renders the message with a Go template such as `{{.name}}`.
+func encodeGoTemplate(v proto.Message, tmpl string) ([]byte, error) {
+	t, err := parseGoTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := object(v)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, obj); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeNDJSON renders each element of a list response, or the message itself, as a line of JSON.
+func encodeNDJSON(v proto.Message) ([]byte, error) {
+	msgs, ok := items(v)
+	if !ok {
+		msgs = []proto.Message{v}
+	}
+	var buf bytes.Buffer
+	for _, m := range msgs {
+		b, err := protojson.MarshalOptions{Resolver: resolver}.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		// protojson may emit insignificant spaces but never newlines without Multiline
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
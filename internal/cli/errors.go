@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Exit codes of kcli. Scripts can tell a failed operation from one that is still running after the timeout.
+const (
+	ExitError           = 1
+	ExitOperationFailed = 2
+	ExitTimeout         = 3
+)
+
+// ExitCode returns the exit code of kcli for the error returned by the command.
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, logrunningoperation.ErrOperationFailed):
+		return ExitOperationFailed
+	case errors.Is(err, logrunningoperation.ErrTimeout):
+		return ExitTimeout
+	default:
+		return ExitError
+	}
+}
+
+// WriteError writes the error returned by the command to w in the output format of the command.
+// It writes a structured error object with PrintJSONError if --output is json, or PrintError otherwise.
+func WriteError(w io.Writer, cmd *cobra.Command, err error) {
+	if f := cmd.Flags().Lookup("output"); f != nil && f.Value.String() == "json" {
+		PrintJSONError(w, err)
+		return
+	}
+	PrintError(w, err)
+}
+
+// jsonError is the structured error object written by PrintJSONError.
+type jsonError struct {
+	Error struct {
+		// Code is the Connect error code such as "not_found", or "unknown" for errors without a code.
+		Code     string `json:"code"`
+		Message  string `json:"message"`
+		ExitCode int    `json:"exitCode"`
+		// Details are the typed error details in the protojson encoding of google.protobuf.Any.
+		Details []json.RawMessage `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// PrintJSONError writes the error to w as a single line JSON object for scripts, such as
+// {"error":{"code":"not_found","message":"...","exitCode":1,"details":[{"@type":"...",...}]}}.
+func PrintJSONError(w io.Writer, err error) {
+	var v jsonError
+	v.Error.Code = connect.CodeOf(err).String()
+	v.Error.Message = err.Error()
+	v.Error.ExitCode = ExitCode(err)
+	var cerr *connect.Error
+	if errors.As(err, &cerr) {
+		for _, d := range cerr.Details() {
+			b, derr := protojson.Marshal(&anypb.Any{
+				TypeUrl: "type.googleapis.com/" + d.Type(),
+				Value:   d.Bytes(),
+			})
+			if derr != nil {
+				continue
+			}
+			v.Error.Details = append(v.Error.Details, b)
+		}
+	}
+	b, merr := json.Marshal(v)
+	if merr != nil {
+		// Never lose the error itself
+		PrintError(w, err)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
+
+// PrintError writes the error to w, followed by the typed details of a Connect error if any.
+func PrintError(w io.Writer, err error) {
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintf(w, "Error: %s: %s\n", cerr.Code(), cerr.Message())
+	for _, d := range cerr.Details() {
+		v, derr := d.Value()
+		if derr != nil {
+			continue
+		}
+		switch v := v.(type) {
+		case *errdetails.BadRequest:
+			for _, f := range v.GetFieldViolations() {
+				fmt.Fprintf(w, "  field %s: %s\n", f.GetField(), f.GetDescription())
+			}
+		case *errdetails.PreconditionFailure:
+			for _, p := range v.GetViolations() {
+				fmt.Fprintf(w, "  precondition %s %s: %s\n", p.GetType(), p.GetSubject(), p.GetDescription())
+			}
+		case *errdetails.QuotaFailure:
+			for _, q := range v.GetViolations() {
+				fmt.Fprintf(w, "  quota %s: %s\n", q.GetSubject(), q.GetDescription())
+			}
+		case *errdetails.RetryInfo:
+			fmt.Fprintf(w, "  retry after: %s\n", v.GetRetryDelay().AsDuration())
+		case *errdetails.ResourceInfo:
+			fmt.Fprintf(w, "  resource: %s (%s)\n", v.GetResourceName(), v.GetResourceType())
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(w, "  reason: %s", v.GetReason())
+			keys := make([]string, 0, len(v.GetMetadata()))
+			for k := range v.GetMetadata() {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Fprintf(w, " %s=%s", k, v.GetMetadata()[k])
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
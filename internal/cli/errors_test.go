@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/logrunningoperation"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	"github.com/spf13/cobra"
+)
+
+func TestPrintError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: "Error: boom\n",
+		},
+		{
+			name: "connect error without details",
+			err:  connect.NewError(connect.CodeUnavailable, errors.New("down")),
+			want: "Error: unavailable: down\n",
+		},
+		{
+			name: "field violation",
+			err:  apierror.InvalidArgument("parent", errors.New("invalid parent")),
+			want: `Error: invalid_argument: invalid parent
+  field parent: invalid parent
+  reason: INVALID_ARGUMENT field=parent
+`,
+		},
+		{
+			name: "resource",
+			err:  apierror.NotFound(apierror.ResourceTypeCluster, "projects/p1/clusters/c1", errors.New("not found")),
+			want: `Error: not_found: not found
+  resource: projects/p1/clusters/c1 (api.proto.v1alpha1.Cluster)
+  reason: RESOURCE_NOT_FOUND resource=projects/p1/clusters/c1
+`,
+		},
+		{
+			name: "precondition",
+			err: apierror.From(apierror.ResourceTypeProject, "projects/p1", &domain.PreconditionError{
+				Type:        "PROJECT_NAMESPACE",
+				Subject:     "namespaces/project-p1",
+				Description: "not provisioned",
+			}),
+			want: `Error: failed_precondition: failed precondition: not provisioned
+  precondition PROJECT_NAMESPACE namespaces/project-p1: not provisioned
+  reason: FAILED_PRECONDITION resource=projects/p1
+`,
+		},
+		{
+			name: "rate limited",
+			err:  apierror.RateLimited(errors.New("slow down"), 2*time.Second),
+			want: `Error: resource_exhausted: slow down
+  retry after: 2s
+  reason: RATE_LIMITED
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintError(&buf, tt.err)
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("PrintError() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "error", err: errors.New("boom"), want: ExitError},
+		{name: "operation failed", err: fmt.Errorf("wrapped: %w", logrunningoperation.ErrOperationFailed), want: ExitOperationFailed},
+		{name: "timeout", err: fmt.Errorf("wrapped: %w", logrunningoperation.ErrTimeout), want: ExitTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintJSONError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "plain error",
+			err:  errors.New("boom"),
+			want: `{"error":{"code":"unknown","message":"boom","exitCode":1}}`,
+		},
+		{
+			name: "operation failed",
+			err:  fmt.Errorf("%w: projects/p1/operations/o1", logrunningoperation.ErrOperationFailed),
+			want: `{"error":{"code":"unknown","message":"operation failed: projects/p1/operations/o1","exitCode":2}}`,
+		},
+		{
+			name: "field violation",
+			err:  fmt.Errorf("failed to get cluster: %w", apierror.InvalidArgument("parent", errors.New("invalid parent"))),
+			want: `{"error":{"code":"invalid_argument","message":"failed to get cluster: invalid_argument: invalid parent","exitCode":1,"details":[
+				{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"parent","description":"invalid parent"}]},
+				{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"INVALID_ARGUMENT","domain":"kaas.nokamoto.github.com","metadata":{"field":"parent"}}
+			]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			PrintJSONError(&buf, tt.err)
+			if !strings.HasSuffix(buf.String(), "\n") || strings.Count(buf.String(), "\n") != 1 {
+				t.Errorf("PrintJSONError() = %q, want a single line", buf.String())
+			}
+			// protojson does not produce a stable output, so compare the decoded objects
+			var got, want any
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("failed to unmarshal output: %v", err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("PrintJSONError() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "human readable by default",
+			args: []string{},
+			want: "Error: boom\n",
+		},
+		{
+			name: "human readable for yaml",
+			args: []string{"--output", "yaml"},
+			want: "Error: boom\n",
+		},
+		{
+			name: "structured for json",
+			args: []string{"--output", "json"},
+			want: `{"error":{"code":"unknown","message":"boom","exitCode":1}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			var out encode.Encoder
+			out.VarP(cmd)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			WriteError(&buf, cmd, errors.New("boom"))
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("WriteError() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package logrunningoperation
+
+import (
+	"path"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+// completeOperationID completes the first argument with the IDs of the operations in the project,
+// described by their phases. Operations that are done are omitted if pending is true, such as for wait.
+// Only the first page of the newest operations is retrieved to keep the completion quick.
+func completeOperationID(r runtime, project *string, pending bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 || *project == "" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		res, err := r.LongRunningOperationService().ListOperations(cmd.Context(), connect.NewRequest(&v1alpha1.ListOperationsRequest{
+			Parent: "projects/" + *project,
+		}))
+		if err != nil {
+			cobra.CompErrorln(err.Error())
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var completions []cobra.Completion
+		for _, op := range res.Msg.GetOperations() {
+			id := path.Base(op.GetName())
+			if !strings.HasPrefix(id, toComplete) || (pending && op.GetDone()) {
+				continue
+			}
+			completions = append(completions, cobra.CompletionWithDesc(id, Phase(op)))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,63 @@
+package logrunningoperation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrTimeout is returned when an operation is not done within the timeout.
+var ErrTimeout = errors.New("timed out waiting for operation")
+
+// Wait polls the operation until it is done, streaming the changes of its phase and conditions to w.
+// It returns ErrTimeout if the operation is not done within the timeout, or waits indefinitely if the timeout is 0.
+// The deadline of each API call, such as --request-timeout, is not the timeout of the wait, so exceeding it is an error of the call.
+// An operation that is already done, such as one returned by a metadata update, is returned as is.
+func Wait(
+	ctx context.Context,
+	client v1alpha1connect.LongRunningOperationServiceClient,
+	op *v1alpha1.LongRunningOperation,
+	timeout time.Duration,
+	w io.Writer,
+) (*v1alpha1.LongRunningOperation, error) {
+	if op.GetDone() {
+		return op, nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, ErrTimeout)
+		defer cancel()
+	}
+	name := op.GetName()
+	s := newSpinner(w)
+	op, err := Poll(ctx, client, name, DefaultBackoff, func(op *v1alpha1.LongRunningOperation) error {
+		s.Update(progress(op))
+		return nil
+	})
+	s.Stop()
+	if err != nil && errors.Is(context.Cause(ctx), ErrTimeout) {
+		return op, fmt.Errorf("%w %s after %s", ErrTimeout, name, timeout)
+	}
+	return op, err
+}
+
+// Response returns the unpacked response of a done operation, or ErrOperationFailed if it has failed.
+func Response(op *v1alpha1.LongRunningOperation) (proto.Message, error) {
+	if err := Result(op); err != nil {
+		return nil, err
+	}
+	if op.GetResponse() == nil {
+		return nil, fmt.Errorf("operation %s has no response", op.GetName())
+	}
+	res, err := op.GetResponse().UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the response of operation %s: %w", op.GetName(), err)
+	}
+	return res, nil
+}
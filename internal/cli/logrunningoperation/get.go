@@ -0,0 +1,32 @@
+package logrunningoperation
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func newGet(r runtime, project *string) *cobra.Command {
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:               "get OPERATION_ID",
+		Short:             "Get a long-running operation",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeOperationID(r, project, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := r.LongRunningOperationService()
+			res, err := service.GetOperation(cmd.Context(), connect.NewRequest(&v1alpha1.GetOperationRequest{
+				Name: operationName(*project, args[0]),
+			}))
+			if err != nil {
+				return fmt.Errorf("failed to get operation: %w", err)
+			}
+			return out.Print(cmd, res.Msg)
+		},
+	}
+	out.VarP(cmd)
+	return cmd
+}
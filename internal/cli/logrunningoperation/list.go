@@ -0,0 +1,41 @@
+package logrunningoperation
+
+import (
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func newList(r runtime, project *string) *cobra.Command {
+	var pageSize int32
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the long-running operations in the project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Follow the page tokens until the last page to print all the operations at once,
+			// or every page as soon as it is retrieved if the output format is streaming
+			all := &v1alpha1.ListOperationsResponse{}
+			err := ListPages(cmd.Context(), r.LongRunningOperationService(), &v1alpha1.ListOperationsRequest{
+				Parent:   "projects/" + *project,
+				PageSize: pageSize,
+			}, func(res *v1alpha1.ListOperationsResponse) error {
+				if out.Streaming() {
+					return out.Print(cmd, &v1alpha1.ListOperationsResponse{Operations: res.GetOperations()})
+				}
+				all.Operations = append(all.Operations, res.GetOperations()...)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if out.Streaming() {
+				return nil
+			}
+			return out.Print(cmd, all)
+		},
+	}
+	cmd.Flags().Int32Var(&pageSize, "page-size", 0, "Number of operations to request per page. All the pages are retrieved regardless. The server chooses if 0")
+	out.VarP(cmd)
+	return cmd
+}
@@ -1,12 +1,33 @@
 package logrunningoperation
 
-import "github.com/spf13/cobra"
+import (
+	"fmt"
 
-func New() *cobra.Command {
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"github.com/spf13/cobra"
+)
+
+type runtime interface {
+	LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient
+}
+
+func New(r runtime) *cobra.Command {
+	var project string
 	cmd := &cobra.Command{
 		Use:     "logrunningoperation",
 		Short:   "Manage long-running operations",
 		Aliases: []string{"operation", "o"},
 	}
+	cmd.PersistentFlags().StringVar(&project, "project", "", "Project ID of the operations")
+	_ = cmd.MarkPersistentFlagRequired("project")
+	cmd.AddCommand(newGet(r, &project))
+	cmd.AddCommand(newList(r, &project))
+	cmd.AddCommand(newWait(r, &project))
+	cmd.AddCommand(newWatch(r, &project))
 	return cmd
 }
+
+// operationName returns the resource name of the operation in the project.
+func operationName(project, operationID string) string {
+	return fmt.Sprintf("projects/%s/operations/%s", project, operationID)
+}
@@ -0,0 +1,358 @@
+package logrunningoperation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"buf.build/go/protoyaml"
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	mockv1alpha1 "github.com/nokamoto/kaas-operator-prototype/internal/mock/mock_v1alpha1connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type mockRuntime struct {
+	client *mockv1alpha1.MockLongRunningOperationServiceClient
+}
+
+func (m *mockRuntime) LongRunningOperationService() v1alpha1connect.LongRunningOperationServiceClient {
+	return m.client
+}
+
+type testcase struct {
+	name    string
+	args    []string
+	mock    func(*mockv1alpha1.MockLongRunningOperationServiceClient)
+	want    []proto.Message
+	wantErr error
+}
+
+const testName = "projects/test-project/operations/cluster-create-123"
+
+func operation(t *testing.T, phase string, done bool) *v1alpha1.LongRunningOperation {
+	t.Helper()
+	m, err := anypb.New(&v1alpha1.LongRunningOperation_Pipeline{
+		Status: &v1alpha1.LongRunningOperation_Pipeline_Status{Phase: phase},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &v1alpha1.LongRunningOperation{Name: testName, Done: done, Metadata: m}
+}
+
+func expectGet(m *mockv1alpha1.MockLongRunningOperationServiceClient, ops ...*v1alpha1.LongRunningOperation) {
+	var calls []any
+	for _, op := range ops {
+		calls = append(calls, m.EXPECT().GetOperation(gomock.Any(), connect.NewRequest(&v1alpha1.GetOperationRequest{
+			Name: testName,
+		})).Return(connect.NewResponse(op), nil))
+	}
+	gomock.InOrder(calls...)
+}
+
+func run(t *testing.T, tt testcase) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := mockv1alpha1.NewMockLongRunningOperationServiceClient(ctrl)
+	if tt.mock != nil {
+		tt.mock(m)
+	}
+
+	cmd := New(&mockRuntime{
+		client: m,
+	})
+	cmd.SetArgs(tt.args)
+
+	var out, stderr bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&stderr)
+	if err := cmd.Execute(); !errors.Is(err, tt.wantErr) {
+		t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+	}
+
+	var got []proto.Message
+	for _, doc := range strings.Split(out.String(), "---\n") {
+		if tt.want == nil {
+			break
+		}
+		msg := tt.want[0].ProtoReflect().New().Interface()
+		if err := protoyaml.Unmarshal([]byte(doc), msg); err != nil {
+			t.Fatalf("failed to unmarshal output: %v", err)
+		}
+		got = append(got, msg)
+	}
+	if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("New() got = %v, want %v, diff: %s", got, tt.want, diff)
+	}
+}
+
+func TestNew_get(t *testing.T) {
+	want := operation(t, "Running", false)
+	tests := []testcase{
+		{
+			name: "got operation",
+			args: []string{"get", "cluster-create-123", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				expectGet(m, want)
+			},
+			want: []proto.Message{want},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_list(t *testing.T) {
+	o1 := &v1alpha1.LongRunningOperation{Name: testName}
+	o2 := &v1alpha1.LongRunningOperation{Name: "projects/test-project/operations/cluster-create-456"}
+	tests := []testcase{
+		{
+			name: "got operations of all the pages",
+			args: []string{"list", "--project", "test-project", "--page-size", "1"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				gomock.InOrder(
+					m.EXPECT().ListOperations(gomock.Any(), connect.NewRequest(&v1alpha1.ListOperationsRequest{
+						Parent:   "projects/test-project",
+						PageSize: 1,
+					})).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{
+						Operations:    []*v1alpha1.LongRunningOperation{o1},
+						NextPageToken: "next",
+					}), nil),
+					m.EXPECT().ListOperations(gomock.Any(), connect.NewRequest(&v1alpha1.ListOperationsRequest{
+						Parent:    "projects/test-project",
+						PageSize:  1,
+						PageToken: "next",
+					})).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{
+						Operations: []*v1alpha1.LongRunningOperation{o2},
+					}), nil),
+				)
+			},
+			want: []proto.Message{&v1alpha1.ListOperationsResponse{
+				Operations: []*v1alpha1.LongRunningOperation{o1, o2},
+			}},
+		},
+		{
+			name: "failed if the server repeats a page token",
+			args: []string{"list", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().ListOperations(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{
+					Operations:    []*v1alpha1.LongRunningOperation{o1},
+					NextPageToken: "next",
+				}), nil).Times(2)
+			},
+			wantErr: ErrUnendingPages,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_wait(t *testing.T) {
+	backoff := DefaultBackoff
+	DefaultBackoff = Backoff{Initial: time.Millisecond, Max: time.Millisecond, Factor: 1}
+	t.Cleanup(func() { DefaultBackoff = backoff })
+
+	succeeded := operation(t, "Succeeded", true)
+	failed := operation(t, "Failed", true)
+	tests := []testcase{
+		{
+			name: "got operation once it is done",
+			args: []string{"wait", "cluster-create-123", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				expectGet(m, operation(t, "Pending", false), operation(t, "Running", false), succeeded)
+			},
+			want: []proto.Message{succeeded},
+		},
+		{
+			name: "failed if the operation has failed",
+			args: []string{"wait", "cluster-create-123", "--project", "test-project"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				expectGet(m, operation(t, "Running", false), failed)
+			},
+			want:    []proto.Message{failed},
+			wantErr: ErrOperationFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestWait_deadline(t *testing.T) {
+	backoff := DefaultBackoff
+	DefaultBackoff = Backoff{Initial: time.Millisecond, Max: time.Millisecond, Factor: 1}
+	t.Cleanup(func() { DefaultBackoff = backoff })
+
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		mock        func(*mockv1alpha1.MockLongRunningOperationServiceClient)
+		wantTimeout bool
+	}{
+		{
+			name:    "timed out if the operation is not done in time",
+			timeout: 10 * time.Millisecond,
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().GetOperation(gomock.Any(), gomock.Any()).Return(connect.NewResponse(operation(t, "Running", false)), nil).AnyTimes()
+			},
+			wantTimeout: true,
+		},
+		{
+			name:    "failed but not timed out if an API call exceeds its own deadline",
+			timeout: time.Minute,
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().GetOperation(gomock.Any(), gomock.Any()).Return(nil, connect.NewError(connect.CodeDeadlineExceeded, context.DeadlineExceeded))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mockv1alpha1.NewMockLongRunningOperationServiceClient(ctrl)
+			tt.mock(m)
+			_, err := Wait(context.Background(), m, &v1alpha1.LongRunningOperation{Name: testName}, tt.timeout, io.Discard)
+			if err == nil {
+				t.Fatal("Wait() error = nil, want an error")
+			}
+			if got := errors.Is(err, ErrTimeout); got != tt.wantTimeout {
+				t.Errorf("Wait() error = %v, is ErrTimeout = %v, want %v", err, got, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestSpinner_terminal(t *testing.T) {
+	var buf bytes.Buffer
+	s := startSpinner(&buf, true)
+	s.Update("Pending")
+	s.Update("Pending")
+	s.Update("Running")
+	s.Update("Succeeded")
+	s.Stop()
+	// Drop the animated frames, which are cleared by the next line
+	var lines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if i := strings.LastIndex(line, "\r\033[K"); i >= 0 {
+			line = line[i+len("\r\033[K"):]
+		}
+		lines = append(lines, line)
+	}
+	want := []string{"Pending", "Running", "Succeeded", ""}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("spinner output mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNew_watch(t *testing.T) {
+	running := operation(t, "Running", false)
+	succeeded := operation(t, "Succeeded", true)
+	tests := []testcase{
+		{
+			name: "got every change of the operation",
+			args: []string{"watch", "cluster-create-123", "--project", "test-project", "--interval", "1ms"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				expectGet(m, running, running, succeeded)
+			},
+			want: []proto.Message{running, succeeded},
+		},
+		{
+			name:    "failed without polling if the interval is not positive",
+			args:    []string{"watch", "cluster-create-123", "--project", "test-project", "--interval", "0"},
+			wantErr: ErrInvalidInterval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run(t, tt)
+		})
+	}
+}
+
+func TestNew_complete(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		mock func(*mockv1alpha1.MockLongRunningOperationServiceClient)
+		want string
+	}{
+		{
+			name: "complete the operations",
+			args: []string{"__complete", "get", "--project", "test-project", "cluster-"},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().ListOperations(gomock.Any(), connect.NewRequest(&v1alpha1.ListOperationsRequest{
+					Parent: "projects/test-project",
+				})).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{
+					Operations: []*v1alpha1.LongRunningOperation{
+						operation(t, "Succeeded", true),
+						{Name: "projects/test-project/operations/other-1"},
+					},
+				}), nil)
+			},
+			want: "cluster-create-123\tSucceeded\n:4\n",
+		},
+		{
+			name: "complete the pending operations to wait for",
+			args: []string{"__complete", "wait", "--project", "test-project", ""},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().ListOperations(gomock.Any(), gomock.Any()).Return(connect.NewResponse(&v1alpha1.ListOperationsResponse{
+					Operations: []*v1alpha1.LongRunningOperation{
+						operation(t, "Succeeded", true),
+						{Name: "projects/test-project/operations/cluster-delete-456"},
+					},
+				}), nil)
+			},
+			want: "cluster-delete-456\n:4\n",
+		},
+		{
+			name: "complete nothing after the operation",
+			args: []string{"__complete", "get", "--project", "test-project", "cluster-create-123", ""},
+			want: ":4\n",
+		},
+		{
+			name: "complete nothing on error",
+			args: []string{"__complete", "watch", "--project", "test-project", ""},
+			mock: func(m *mockv1alpha1.MockLongRunningOperationServiceClient) {
+				m.EXPECT().ListOperations(gomock.Any(), gomock.Any()).Return(nil, connect.NewError(connect.CodeUnavailable, errors.New("down")))
+			},
+			want: ":1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := mockv1alpha1.NewMockLongRunningOperationServiceClient(ctrl)
+			if tt.mock != nil {
+				tt.mock(m)
+			}
+			cmd := New(&mockRuntime{client: m})
+			cmd.SetArgs(tt.args)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			if err := cmd.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("completion mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
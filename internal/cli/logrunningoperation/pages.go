@@ -0,0 +1,48 @@
+package logrunningoperation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+// maxPages is the maximum number of pages ListPages retrieves.
+const maxPages = 1000
+
+// ErrUnendingPages is returned if the server keeps returning next page tokens,
+// either a token it has already returned or more than maxPages of them.
+var ErrUnendingPages = errors.New("the pages of the operations do not end")
+
+// ListPages lists the operations of the request from the first page to the last one and calls page for each page.
+// It stops with ErrUnendingPages rather than following the page tokens forever.
+func ListPages(
+	ctx context.Context,
+	service v1alpha1connect.LongRunningOperationServiceClient,
+	req *v1alpha1.ListOperationsRequest,
+	page func(*v1alpha1.ListOperationsResponse) error,
+) error {
+	seen := map[string]bool{}
+	for range maxPages {
+		res, err := service.ListOperations(ctx, connect.NewRequest(req))
+		if err != nil {
+			return fmt.Errorf("failed to list operations: %w", err)
+		}
+		if err := page(res.Msg); err != nil {
+			return err
+		}
+		token := res.Msg.GetNextPageToken()
+		if token == "" {
+			return nil
+		}
+		if seen[token] {
+			return fmt.Errorf("%w: the page token %q is repeated", ErrUnendingPages, token)
+		}
+		seen[token] = true
+		req.PageToken = token
+	}
+	return fmt.Errorf("%w: more than %d pages", ErrUnendingPages, maxPages)
+}
@@ -0,0 +1,88 @@
+package logrunningoperation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+// ErrOperationFailed is returned when an operation is done but has failed.
+var ErrOperationFailed = errors.New("operation failed")
+
+// Backoff is the interval between the polls of an operation, growing by Factor from Initial up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+	Factor  float64
+}
+
+// DefaultBackoff polls frequently at first since metadata updates are fast, then settles to every 10 seconds.
+var DefaultBackoff = Backoff{
+	Initial: time.Second,
+	Max:     10 * time.Second,
+	Factor:  1.5,
+}
+
+func (b Backoff) next(d time.Duration) time.Duration {
+	return min(time.Duration(float64(d)*b.Factor), b.Max)
+}
+
+// Poll gets the operation repeatedly until it is done or the context is done.
+// It calls onUpdate with every retrieved operation, including the last one, and returns the last one.
+// Polling stops with the error returned by onUpdate, if any.
+func Poll(
+	ctx context.Context,
+	client v1alpha1connect.LongRunningOperationServiceClient,
+	name string,
+	backoff Backoff,
+	onUpdate func(*v1alpha1.LongRunningOperation) error,
+) (*v1alpha1.LongRunningOperation, error) {
+	interval := backoff.Initial
+	for {
+		res, err := client.GetOperation(ctx, connect.NewRequest(&v1alpha1.GetOperationRequest{Name: name}))
+		if err != nil {
+			if ctx.Err() != nil {
+				// Report the context error rather than the RPC failure it caused
+				return nil, fmt.Errorf("operation %s is not done: %w", name, ctx.Err())
+			}
+			return nil, fmt.Errorf("failed to get operation: %w", err)
+		}
+		op := res.Msg
+		if onUpdate != nil {
+			if err := onUpdate(op); err != nil {
+				return op, err
+			}
+		}
+		if op.GetDone() {
+			return op, nil
+		}
+		select {
+		case <-ctx.Done():
+			return op, fmt.Errorf("operation %s is not done: %w", name, ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = backoff.next(interval)
+	}
+}
+
+// Phase returns the phase of the pipeline running the operation, or an empty string if unknown.
+func Phase(op *v1alpha1.LongRunningOperation) string {
+	var pipeline v1alpha1.LongRunningOperation_Pipeline
+	if op.GetMetadata() == nil || op.GetMetadata().UnmarshalTo(&pipeline) != nil {
+		return ""
+	}
+	return pipeline.GetStatus().GetPhase()
+}
+
+// Result returns ErrOperationFailed if the operation is done but has failed.
+func Result(op *v1alpha1.LongRunningOperation) error {
+	if op.GetDone() && Phase(op) == "Failed" {
+		return fmt.Errorf("%w: %s", ErrOperationFailed, op.GetName())
+	}
+	return nil
+}
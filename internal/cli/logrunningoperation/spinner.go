@@ -0,0 +1,92 @@
+package logrunningoperation
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinner shows the progress of a wait on the writer.
+// It writes a line per distinct message so that every phase change is kept, and on a terminal
+// it animates the current message until it is replaced by the next one or the spinner stops.
+type spinner struct {
+	w        io.Writer
+	terminal bool
+
+	mu      sync.Mutex
+	message string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newSpinner(w io.Writer) *spinner {
+	f, ok := w.(*os.File)
+	return startSpinner(w, ok && term.IsTerminal(int(f.Fd())))
+}
+
+func startSpinner(w io.Writer, terminal bool) *spinner {
+	s := &spinner{
+		w:        w,
+		terminal: terminal,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if !s.terminal {
+		close(s.done)
+		return s
+	}
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		s.mu.Lock()
+		fmt.Fprintf(s.w, "\r\033[K%s %s", spinnerFrames[i%len(spinnerFrames)], s.message)
+		s.mu.Unlock()
+		select {
+		case <-s.stop:
+			fmt.Fprint(s.w, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Update sets the message shown next to the spinner.
+func (s *spinner) Update(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if message == s.message {
+		return
+	}
+	if !s.terminal {
+		fmt.Fprintln(s.w, message)
+	} else if s.message != "" {
+		// Replace the animated line with the previous message to keep it above the next one
+		fmt.Fprintf(s.w, "\r\033[K%s\n", s.message)
+	}
+	s.message = message
+}
+
+// Stop stops the animation and replaces the animated line with the last message.
+func (s *spinner) Stop() {
+	if s.terminal {
+		close(s.stop)
+	}
+	<-s.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.terminal && s.message != "" {
+		fmt.Fprintln(s.w, s.message)
+	}
+}
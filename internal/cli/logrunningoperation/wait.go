@@ -0,0 +1,52 @@
+package logrunningoperation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+func newWait(r runtime, project *string) *cobra.Command {
+	var timeout time.Duration
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:               "wait OPERATION_ID",
+		Short:             "Wait for a long-running operation to be done",
+		Long:              "Wait for a long-running operation to be done and print it. It exits with a non-zero status if the operation has failed.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeOperationID(r, project, true),
+		// A failed operation is not a usage error
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			op := &v1alpha1.LongRunningOperation{Name: operationName(*project, args[0])}
+			op, err := Wait(cmd.Context(), r.LongRunningOperationService(), op, timeout, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			if err := out.Print(cmd, op); err != nil {
+				return err
+			}
+			return Result(op)
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum duration to wait for. Waits indefinitely if 0")
+	out.VarP(cmd)
+	return cmd
+}
+
+// progress returns a one-line summary of the phase and the latest condition of the operation for the spinner.
+func progress(op *v1alpha1.LongRunningOperation) string {
+	var pipeline v1alpha1.LongRunningOperation_Pipeline
+	if op.GetMetadata() == nil || op.GetMetadata().UnmarshalTo(&pipeline) != nil {
+		return fmt.Sprintf("Waiting for operation %s", op.GetName())
+	}
+	s := fmt.Sprintf("Waiting for operation %s: %s", op.GetName(), pipeline.GetStatus().GetPhase())
+	conditions := pipeline.GetStatus().GetConditions()
+	if len(conditions) > 0 {
+		s += ": " + conditions[len(conditions)-1].GetMessage()
+	}
+	return s
+}
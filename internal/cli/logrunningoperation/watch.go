@@ -0,0 +1,56 @@
+package logrunningoperation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/cli/encode"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidInterval is returned if the interval between the polls is not positive, which would poll without a pause.
+var ErrInvalidInterval = errors.New("invalid interval")
+
+func newWatch(r runtime, project *string) *cobra.Command {
+	var interval time.Duration
+	var out encode.Encoder
+	cmd := &cobra.Command{
+		Use:               "watch OPERATION_ID",
+		Short:             "Print a long-running operation every time it changes until it is done",
+		Long:              "Print a long-running operation every time it changes until it is done. It exits with a non-zero status if the operation has failed.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeOperationID(r, project, true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("%w: --interval must be positive, got %s", ErrInvalidInterval, interval)
+			}
+			// A failed operation is not a usage error
+			cmd.SilenceUsage = true
+			var last *v1alpha1.LongRunningOperation
+			op, err := Poll(cmd.Context(), r.LongRunningOperationService(), operationName(*project, args[0]), Backoff{
+				Initial: interval,
+				Max:     interval,
+				Factor:  1,
+			}, func(op *v1alpha1.LongRunningOperation) error {
+				if proto.Equal(last, op) {
+					return nil
+				}
+				if last != nil {
+					cmd.Print(out.Separator())
+				}
+				last = op
+				return out.Print(cmd, op)
+			})
+			if err != nil {
+				return err
+			}
+			return Result(op)
+		},
+	}
+	cmd.Flags().DurationVar(&interval, "interval", 2*time.Second, "Interval between the polls of the operation")
+	out.VarP(cmd)
+	return cmd
+}
@@ -2,22 +2,60 @@ package boilerplate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 )
 
+// SetupFunc registers reconcilers or runnables with the manager.
+type SetupFunc func(ctrl.Manager, Options) error
+
 // V1alpha1Controller sets up the v1alpha1 controller with the provided setup function.
-// It initializes the scheme, sets up logging, creates a manager, and starts the controller.
+// It loads the Options from the command line arguments and runs the manager with Run.
 // This function is intended to be used in the main function of the controller.
-func V1alpha1Controller(setupWithManager ...func(ctrl.Manager) error) {
+func V1alpha1Controller(setupWithManager ...SetupFunc) {
+	Run(MustLoadOptions(os.Args[1:]), setupWithManager...)
+}
+
+// MustLoadOptions is like LoadOptions but exits the process if the options cannot be loaded.
+// It exits with 0 if --help is given.
+func MustLoadOptions(args []string, bindFlags ...func(*pflag.FlagSet)) Options {
+	opts, err := LoadOptions(args, bindFlags...)
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to load options: %v\n", err)
+		os.Exit(1)
+	}
+	return opts
+}
+
+// Run initializes the scheme, sets up logging and tracing, creates a manager with health probes,
+// calls the setup functions, and starts the manager until a termination signal is received.
+// It exits the process on failure.
+func Run(opts Options, setupWithManager ...SetupFunc) {
+	log, err := opts.Logger()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to create logger: %v\n", err)
+		os.Exit(1)
+	}
+	ctrl.SetLogger(log)
 	logger := ctrl.Log.WithName("setup")
 
 	scheme := runtime.NewScheme()
@@ -30,30 +68,54 @@ func V1alpha1Controller(setupWithManager ...func(ctrl.Manager) error) {
 		os.Exit(1)
 	}
 
-	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-	})
+	ctx := ctrl.SetupSignalHandler()
+	shutdown, err := tracing.Setup(ctx, filepath.Base(os.Args[0]))
+	if err != nil {
+		logger.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdown(context.Background()); err != nil {
+			logger.Error(err, "unable to shut down tracing")
+		}
+	}()
+
+	mgrOpts := opts.ManagerOptions()
+	mgrOpts.Scheme = scheme
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOpts)
 	if err != nil {
 		logger.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		logger.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		logger.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
 
 	for _, setup := range setupWithManager {
-		if err := setup(mgr); err != nil {
+		if err := setup(mgr, opts); err != nil {
 			logger.Error(err, "unable to create controller")
 			os.Exit(1)
 		}
 	}
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		logger.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
 
+// conditionTypeFailed is the condition type shared by all v1alpha1 resources to report a failure.
+const conditionTypeFailed = "Failed"
+
 type statusSetter interface {
+	GetPhase() string
 	SetPhase(string)
+	GetConditions() []metav1.Condition
 	AddCondition(metav1.Condition)
 	SetLastSyncedTime(metav1.Time)
 }
@@ -65,14 +127,17 @@ type statusSetterObject interface {
 
 type StatusUpdater[A statusSetterObject, B ~string] struct {
 	client.Client
+	recorder record.EventRecorder
 }
 
-func NewStatusUpdater[A statusSetterObject, B ~string](client client.Client) *StatusUpdater[A, B] {
-	return &StatusUpdater[A, B]{Client: client}
+func NewStatusUpdater[A statusSetterObject, B ~string](client client.Client, recorder record.EventRecorder) *StatusUpdater[A, B] {
+	return &StatusUpdater[A, B]{Client: client, recorder: recorder}
 }
 
 // UpdateStatus updates the status of the given object with the provided phase and condition.
 // It sets the phase, adds the condition, and updates the last synced time.
+// If the phase has changed, it emits an event for the transition on the object and records the time spent in the previous phase,
+// and the cluster creation latency if a create pipeline has completed.
 func (u *StatusUpdater[A, B]) Update(
 	ctx context.Context,
 	obj A,
@@ -80,6 +145,8 @@ func (u *StatusUpdater[A, B]) Update(
 	condition *metav1.Condition,
 ) error {
 	now := metav1.Now()
+	previous := obj.GetPhase()
+	since := phaseStartTime(obj)
 	obj.SetPhase(string(phase))
 	if condition != nil {
 		condition.LastTransitionTime = now
@@ -87,7 +154,60 @@ func (u *StatusUpdater[A, B]) Update(
 	}
 	obj.SetLastSyncedTime(now)
 	if err := u.Status().Update(ctx, obj); err != nil {
+		u.recorder.Eventf(obj, corev1.EventTypeWarning, "StatusUpdateFailed", "Failed to update phase to %s: %v", phase, err)
 		return fmt.Errorf("failed to update status: %w", err)
 	}
+	if previous != string(phase) {
+		u.recordTransition(obj, previous, string(phase), condition)
+		if previous != "" {
+			metrics.PhaseDuration.WithLabelValues(metrics.Kind(obj), previous).Observe(now.Sub(since).Seconds())
+		}
+		observeClusterCreation(obj, string(phase), now.Time)
+	}
 	return nil
 }
+
+// observeClusterCreation records the end-to-end latency of a cluster creation once its pipeline has succeeded or failed,
+// wherever the pipeline moves to the phase.
+func observeClusterCreation(obj statusSetterObject, phase string, now time.Time) {
+	pipeline, ok := obj.(*v1alpha1.Pipeline)
+	if !ok || pipeline.IsDelete() {
+		return
+	}
+	var result string
+	switch v1alpha1.PipelinePhase(phase) {
+	case v1alpha1.PipelinePhaseSucceeded:
+		result = "succeeded"
+	case v1alpha1.PipelinePhaseFailed:
+		result = "failed"
+	default:
+		return
+	}
+	metrics.ClusterCreationDuration.WithLabelValues(result).Observe(now.Sub(pipeline.CreationTimestamp.Time).Seconds())
+}
+
+// phaseStartTime returns the time when the object entered its current phase.
+// A condition is added on every phase transition, so the transition time of the last condition is used if present.
+func phaseStartTime(obj statusSetterObject) time.Time {
+	conditions := obj.GetConditions()
+	if len(conditions) == 0 {
+		return obj.GetCreationTimestamp().Time
+	}
+	return conditions[len(conditions)-1].LastTransitionTime.Time
+}
+
+// recordTransition emits an event for the phase transition of the given object.
+// The event is a warning if the condition reports a failure, otherwise it is a normal event.
+func (u *StatusUpdater[A, B]) recordTransition(obj A, previous, phase string, condition *metav1.Condition) {
+	eventType := corev1.EventTypeNormal
+	reason := "PhaseChanged"
+	message := fmt.Sprintf("Phase changed from %q to %q", previous, phase)
+	if condition != nil {
+		if condition.Type == conditionTypeFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		reason = condition.Reason
+		message = fmt.Sprintf("%s: %s", message, condition.Message)
+	}
+	u.recorder.Event(obj, eventType, reason, message)
+}
@@ -0,0 +1,53 @@
+package boilerplate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestStatusUpdater_Update_clusterCreation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation v1alpha1.PipelineOperation
+		phase     v1alpha1.PipelinePhase
+		want      string
+	}{
+		{name: "create pipeline has failed", phase: v1alpha1.PipelinePhaseFailed, want: "failed"},
+		{name: "create pipeline has succeeded", phase: v1alpha1.PipelinePhaseSucceeded, want: "succeeded"},
+		{name: "create pipeline is running", phase: v1alpha1.PipelinePhaseRunning},
+		{name: "delete pipeline has failed", operation: v1alpha1.PipelineOperationDelete, phase: v1alpha1.PipelinePhaseFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := v1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			pipeline := &v1alpha1.Pipeline{
+				ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: "project-p1"},
+				Spec:       v1alpha1.PipelineSpec{Operation: tt.operation},
+				Status:     v1alpha1.PipelineStatus{Phase: v1alpha1.PipelinePhasePending},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pipeline).WithStatusSubresource(pipeline).Build()
+			u := NewStatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase](c, record.NewFakeRecorder(10))
+
+			metrics.ClusterCreationDuration.Reset()
+			if err := u.Update(context.Background(), pipeline, tt.phase, nil); err != nil {
+				t.Fatal(err)
+			}
+			// A result is only deletable if it has been observed since the reset
+			for _, result := range []string{"failed", "succeeded"} {
+				if got := metrics.ClusterCreationDuration.DeleteLabelValues(result); got != (result == tt.want) {
+					t.Errorf("ClusterCreationDuration observed %s = %v, want %v", result, got, result == tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package boilerplate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/yaml"
+)
+
+// EnvPrefix is the prefix of the environment variables that override the options.
+// For example, --leader-elect is overridden by KAAS_LEADER_ELECT.
+const EnvPrefix = "KAAS_"
+
+// Options configures the controller manager shared by all controller binaries.
+//
+// The options are resolved in the following order, where later ones take precedence:
+// defaults, the config file given by --config (or KAAS_CONFIG), environment variables, and command line flags.
+type Options struct {
+	// MetricsBindAddress is the address the metrics endpoint binds to. "0" disables the endpoint.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
+	// HealthProbeBindAddress is the address the /healthz and /readyz endpoints bind to. "0" disables the endpoints.
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+	// LeaderElection enables leader election so that only one replica reconciles at a time.
+	LeaderElection bool `json:"leaderElection,omitempty"`
+	// LeaderElectionID is the name of the lease used for leader election.
+	// If not set, it defaults to the binary name.
+	LeaderElectionID string `json:"leaderElectionID,omitempty"`
+	// LeaderElectionNamespace is the namespace of the lease.
+	// If not set, it defaults to the namespace the manager runs in.
+	LeaderElectionNamespace string `json:"leaderElectionNamespace,omitempty"`
+	// Namespaces restricts the namespaces to watch. All namespaces are watched if empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// LogFormat is the log encoding, either "json" or "console".
+	LogFormat string `json:"logFormat,omitempty"`
+	// LogLevel is the minimum log level, such as "debug", "info" or "error".
+	LogLevel string `json:"logLevel,omitempty"`
+	// MaxConcurrentReconciles is the maximum number of concurrent reconciles per controller.
+	// It does not apply to the pipeline queue controller, which always reconciles one pipeline at a time
+	// to start the pending pipelines of a project in order.
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// PollingInterval is the interval at which the controllers requeue resources in a non-terminal phase.
+	PollingInterval metav1.Duration `json:"pollingInterval,omitempty"`
+	// SyncPeriod is the minimum interval at which watched resources are reconciled. 0 uses the controller-runtime default.
+	SyncPeriod metav1.Duration `json:"syncPeriod,omitempty"`
+}
+
+// NewOptions returns the default options.
+func NewOptions() Options {
+	return Options{
+		MetricsBindAddress:      ":8080",
+		HealthProbeBindAddress:  ":8081",
+		LogFormat:               "json",
+		LogLevel:                "info",
+		MaxConcurrentReconciles: 1,
+		PollingInterval:         metav1.Duration{Duration: 10 * time.Second},
+	}
+}
+
+// LoadOptions resolves the options from the config file, the environment variables and the given command line arguments.
+// bindFlags registers additional flags of the binary, which are resolved from the environment variables
+// and the command line arguments in the same way as the options.
+func LoadOptions(args []string, bindFlags ...func(*pflag.FlagSet)) (Options, error) {
+	opts := NewOptions()
+
+	configFile, err := configFileFrom(args)
+	if err != nil {
+		return opts, err
+	}
+	if configFile != "" {
+		data, err := os.ReadFile(configFile)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := yaml.UnmarshalStrict(data, &opts); err != nil {
+			return opts, fmt.Errorf("failed to parse config file %s: %w", configFile, err)
+		}
+	}
+
+	fs := pflag.NewFlagSet(filepath.Base(os.Args[0]), pflag.ContinueOnError)
+	opts.BindFlags(fs)
+	for _, bind := range bindFlags {
+		bind(fs)
+	}
+	var errs []error
+	fs.VisitAll(func(f *pflag.Flag) {
+		if v, ok := os.LookupEnv(envName(f.Name)); ok {
+			if err := fs.Set(f.Name, v); err != nil {
+				errs = append(errs, fmt.Errorf("invalid %s: %w", envName(f.Name), err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return opts, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	return opts, nil
+}
+
+// BindFlags registers the options as flags with the current values as defaults.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.String("config", "", "Path to a YAML config file with the options")
+	fs.StringVar(&o.MetricsBindAddress, "metrics-bind-address", o.MetricsBindAddress, "The address the metrics endpoint binds to. Use 0 to disable it")
+	fs.StringVar(&o.HealthProbeBindAddress, "health-probe-bind-address", o.HealthProbeBindAddress, "The address the health probe endpoints bind to. Use 0 to disable them")
+	fs.BoolVar(&o.LeaderElection, "leader-elect", o.LeaderElection, "Enable leader election to ensure only one active controller manager")
+	fs.StringVar(&o.LeaderElectionID, "leader-election-id", o.LeaderElectionID, "The name of the lease used for leader election. Defaults to the binary name")
+	fs.StringVar(&o.LeaderElectionNamespace, "leader-election-namespace", o.LeaderElectionNamespace, "The namespace of the lease used for leader election")
+	fs.StringSliceVar(&o.Namespaces, "namespaces", o.Namespaces, "Namespaces to watch. All namespaces are watched if empty")
+	fs.StringVar(&o.LogFormat, "log-format", o.LogFormat, "Log format (json, console)")
+	fs.StringVar(&o.LogLevel, "log-level", o.LogLevel, "Log level (debug, info, error)")
+	fs.IntVar(&o.MaxConcurrentReconciles, "max-concurrent-reconciles", o.MaxConcurrentReconciles, "Maximum number of concurrent reconciles per controller, except the pipeline queue controller which always runs one")
+	fs.DurationVar(&o.PollingInterval.Duration, "polling-interval", o.PollingInterval.Duration, "Interval at which resources in a non-terminal phase are requeued")
+	fs.DurationVar(&o.SyncPeriod.Duration, "sync-period", o.SyncPeriod.Duration, "Minimum interval at which watched resources are reconciled. 0 uses the default")
+}
+
+// configFileFrom returns the config file path from the command line arguments or the environment variable.
+func configFileFrom(args []string) (string, error) {
+	fs := pflag.NewFlagSet("config", pflag.ContinueOnError)
+	fs.ParseErrorsWhitelist.UnknownFlags = true
+	fs.Usage = func() {}
+	configFile := fs.String("config", os.Getenv(envName("config")), "")
+	if err := fs.Parse(args); err != nil && !errors.Is(err, pflag.ErrHelp) {
+		return "", err
+	}
+	return *configFile, nil
+}
+
+func envName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Logger returns a logger configured with the log format and level.
+func (o *Options) Logger() (logr.Logger, error) {
+	level, err := zapcore.ParseLevel(o.LogLevel)
+	if err != nil {
+		return logr.Logger{}, fmt.Errorf("invalid log level: %w", err)
+	}
+	zapOpts := []zap.Opts{zap.Level(level)}
+	switch o.LogFormat {
+	case "json":
+		zapOpts = append(zapOpts, zap.JSONEncoder())
+	case "console":
+		zapOpts = append(zapOpts, zap.ConsoleEncoder())
+	default:
+		return logr.Logger{}, fmt.Errorf("unknown log format: %s, must be one of json, console", o.LogFormat)
+	}
+	return zap.New(zapOpts...), nil
+}
+
+// ManagerOptions returns the controller-runtime manager options.
+func (o *Options) ManagerOptions() ctrl.Options {
+	id := o.LeaderElectionID
+	if id == "" {
+		id = filepath.Base(os.Args[0])
+	}
+	opts := ctrl.Options{
+		Metrics: metricsserver.Options{
+			BindAddress: o.MetricsBindAddress,
+		},
+		HealthProbeBindAddress:  o.HealthProbeBindAddress,
+		LeaderElection:          o.LeaderElection,
+		LeaderElectionID:        id,
+		LeaderElectionNamespace: o.LeaderElectionNamespace,
+		Controller: config.Controller{
+			MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+		},
+	}
+	if o.SyncPeriod.Duration > 0 {
+		opts.Cache.SyncPeriod = &o.SyncPeriod.Duration
+	}
+	if len(o.Namespaces) > 0 {
+		opts.Cache.DefaultNamespaces = map[string]cache.Config{}
+		for _, ns := range o.Namespaces {
+			opts.Cache.DefaultNamespaces[ns] = cache.Config{}
+		}
+	}
+	return opts
+}
@@ -0,0 +1,129 @@
+package boilerplate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLoadOptions(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	type testcase struct {
+		name    string
+		config  string
+		env     map[string]string
+		args    []string
+		want    func(*Options)
+		wantErr bool
+	}
+	tests := []testcase{
+		{
+			name: "defaults",
+			want: func(*Options) {},
+		},
+		{
+			name: "flags",
+			args: []string{"--leader-elect", "--namespaces", "a,b", "--polling-interval", "1s", "--max-concurrent-reconciles", "4"},
+			want: func(o *Options) {
+				o.LeaderElection = true
+				o.Namespaces = []string{"a", "b"}
+				o.PollingInterval = metav1.Duration{Duration: time.Second}
+				o.MaxConcurrentReconciles = 4
+			},
+		},
+		{
+			name: "config file overrides defaults",
+			config: `
+logFormat: console
+pollingInterval: 5s
+namespaces:
+  - tenant
+`,
+			want: func(o *Options) {
+				o.LogFormat = "console"
+				o.PollingInterval = metav1.Duration{Duration: 5 * time.Second}
+				o.Namespaces = []string{"tenant"}
+			},
+		},
+		{
+			name:   "env overrides config file",
+			config: "logLevel: debug\nlogFormat: console\n",
+			env:    map[string]string{"KAAS_LOG_LEVEL": "error"},
+			want: func(o *Options) {
+				o.LogFormat = "console"
+				o.LogLevel = "error"
+			},
+		},
+		{
+			name: "flags override env",
+			env:  map[string]string{"KAAS_LEADER_ELECTION_ID": "from-env"},
+			args: []string{"--leader-election-id", "from-flag"},
+			want: func(o *Options) {
+				o.LeaderElectionID = "from-flag"
+			},
+		},
+		{
+			name:    "unknown field in config file",
+			config:  "unknown: true\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid env",
+			env:     map[string]string{"KAAS_MAX_CONCURRENT_RECONCILES": "many"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			args := tt.args
+			if tt.config != "" {
+				args = append([]string{"--config", writeConfig(t, tt.config)}, args...)
+			}
+			got, err := LoadOptions(args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			want := NewOptions()
+			tt.want(&want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("LoadOptions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoadOptions_bindFlags(t *testing.T) {
+	t.Setenv("KAAS_CONTROLLERS", "a,b")
+	var controllers []string
+	var addr string
+	_, err := LoadOptions([]string{"--api-bind-address", ":9090"}, func(fs *pflag.FlagSet) {
+		fs.StringSliceVar(&controllers, "controllers", nil, "")
+		fs.StringVar(&addr, "api-bind-address", "", "")
+	})
+	if err != nil {
+		t.Fatalf("LoadOptions() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, controllers); diff != "" {
+		t.Errorf("controllers mismatch (-want +got):\n%s", diff)
+	}
+	if addr != ":9090" {
+		t.Errorf("api-bind-address = %q, want %q", addr, ":9090")
+	}
+}
@@ -7,7 +7,10 @@ import (
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -15,6 +18,8 @@ import (
 
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusters/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 type KubernetesClusterReconciler struct {
 	client.Client
@@ -28,15 +33,16 @@ type KubernetesClusterReconcilerOptions struct {
 	PollingInterval time.Duration
 }
 
-func NewKubernetesClusterReconciler(client client.Client, opts KubernetesClusterReconcilerOptions) *KubernetesClusterReconciler {
+func NewKubernetesClusterReconciler(client client.Client, recorder record.EventRecorder, opts KubernetesClusterReconcilerOptions) *KubernetesClusterReconciler {
+	recorder = metrics.NewEventRecorder(recorder)
 	return &KubernetesClusterReconciler{
 		Client: client,
-		status: boilerplate.NewStatusUpdater[*v1alpha1.KubernetesCluster, v1alpha1.KubernetesClusterPhase](client),
+		status: boilerplate.NewStatusUpdater[*v1alpha1.KubernetesCluster, v1alpha1.KubernetesClusterPhase](client, recorder),
 		opts:   opts,
 	}
 }
 
-func (r *KubernetesClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KubernetesClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling KubernetesCluster")
 	// Fetch the KubernetesCluster instance
@@ -45,6 +51,8 @@ func (r *KubernetesClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		logger.Error(err, "unable to fetch KubernetesCluster")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx, span := tracing.Start(ctx, kubernetesCluster, "KubernetesClusterReconciler.Reconcile")
+	defer func() { tracing.End(span, err) }()
 	logger = logger.WithValues("phase", kubernetesCluster.Status.Phase)
 
 	switch kubernetesCluster.Status.Phase {
@@ -94,3 +102,12 @@ func (r *KubernetesClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&v1alpha1.KubernetesCluster{}).
 		Complete(r)
 }
+
+// Setup registers the KubernetesClusterReconciler with the manager.
+func Setup(mgr ctrl.Manager, o boilerplate.Options) error {
+	opts := KubernetesClusterReconcilerOptions{
+		PollingInterval: o.PollingInterval.Duration,
+	}
+	r := NewKubernetesClusterReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("kubernetescluster-controller"), opts)
+	return r.SetupWithManager(mgr)
+}
@@ -7,7 +7,11 @@ import (
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -16,11 +20,14 @@ import (
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusterconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusterconfigurations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 type KubernetesClusterConfigurationReconciler struct {
 	client.Client
-	status *boilerplate.StatusUpdater[*v1alpha1.KubernetesClusterConfiguration, v1alpha1.KubernetesClusterConfigurationPhase]
-	opts   KubernetesClusterConfigurationReconcilerOptions
+	recorder record.EventRecorder
+	status   *boilerplate.StatusUpdater[*v1alpha1.KubernetesClusterConfiguration, v1alpha1.KubernetesClusterConfigurationPhase]
+	opts     KubernetesClusterConfigurationReconcilerOptions
 }
 
 type KubernetesClusterConfigurationReconcilerOptions struct {
@@ -29,15 +36,17 @@ type KubernetesClusterConfigurationReconcilerOptions struct {
 	PollingInterval time.Duration
 }
 
-func NewKubernetesClusterConfigurationReconciler(client client.Client, opts KubernetesClusterConfigurationReconcilerOptions) *KubernetesClusterConfigurationReconciler {
+func NewKubernetesClusterConfigurationReconciler(client client.Client, recorder record.EventRecorder, opts KubernetesClusterConfigurationReconcilerOptions) *KubernetesClusterConfigurationReconciler {
+	recorder = metrics.NewEventRecorder(recorder)
 	return &KubernetesClusterConfigurationReconciler{
-		Client: client,
-		status: boilerplate.NewStatusUpdater[*v1alpha1.KubernetesClusterConfiguration, v1alpha1.KubernetesClusterConfigurationPhase](client),
-		opts:   opts,
+		Client:   client,
+		recorder: recorder,
+		status:   boilerplate.NewStatusUpdater[*v1alpha1.KubernetesClusterConfiguration, v1alpha1.KubernetesClusterConfigurationPhase](client, recorder),
+		opts:     opts,
 	}
 }
 
-func (r *KubernetesClusterConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *KubernetesClusterConfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling KubernetesClusterConfiguration")
 	// Fetch the KubernetesClusterConfiguration instance
@@ -46,6 +55,8 @@ func (r *KubernetesClusterConfigurationReconciler) Reconcile(ctx context.Context
 		logger.Error(err, "unable to fetch KubernetesClusterConfiguration")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx, span := tracing.Start(ctx, kcc, "KubernetesClusterConfigurationReconciler.Reconcile")
+	defer func() { tracing.End(span, err) }()
 	logger = logger.WithValues("phase", kcc.Status.Phase)
 	switch kcc.Status.Phase {
 	case v1alpha1.KubernetesClusterConfigurationPhaseCreating:
@@ -56,6 +67,7 @@ func (r *KubernetesClusterConfigurationReconciler) Reconcile(ctx context.Context
 		if err := r.Get(ctx, client.ObjectKey{Namespace: kcc.Namespace, Name: name}, kccm); err != nil {
 			if client.IgnoreNotFound(err) != nil {
 				logger.Error(err, "failed to get KubernetesClusterConfigurationConfigMap")
+				r.recorder.Eventf(kcc, corev1.EventTypeWarning, "KubernetesClusterConfigurationConfigMapGetFailed", "Failed to get KubernetesClusterConfigurationConfigMap %s: %v", name, err)
 				return ctrl.Result{}, err
 			}
 			// Create the KubernetesClusterConfigurationConfigMap with owner reference
@@ -74,8 +86,10 @@ func (r *KubernetesClusterConfigurationReconciler) Reconcile(ctx context.Context
 			logger.Info("KubernetesClusterConfigurationConfigMap does not exist, creating it")
 			if err := r.Create(ctx, kccm); err != nil {
 				logger.Error(err, "failed to create KubernetesClusterConfigurationConfigMap")
+				r.recorder.Eventf(kcc, corev1.EventTypeWarning, "KubernetesClusterConfigurationConfigMapCreateFailed", "Failed to create KubernetesClusterConfigurationConfigMap %s: %v", name, err)
 				return ctrl.Result{}, fmt.Errorf("failed to create KubernetesClusterConfigurationConfigMap: %w", err)
 			}
+			r.recorder.Eventf(kcc, corev1.EventTypeNormal, "KubernetesClusterConfigurationConfigMapCreated", "Created KubernetesClusterConfigurationConfigMap %s", name)
 			// Requeue to wait for the KubernetesClusterConfigurationConfigMap to be created
 			return ctrl.Result{RequeueAfter: r.opts.PollingInterval}, nil
 		}
@@ -127,3 +141,12 @@ func (r *KubernetesClusterConfigurationReconciler) SetupWithManager(mgr ctrl.Man
 		Owns(&v1alpha1.KubernetesClusterConfigurationConfigMap{}).
 		Complete(r)
 }
+
+// Setup registers the KubernetesClusterConfigurationReconciler with the manager.
+func Setup(mgr ctrl.Manager, o boilerplate.Options) error {
+	opts := KubernetesClusterConfigurationReconcilerOptions{
+		PollingInterval: o.PollingInterval.Duration,
+	}
+	r := NewKubernetesClusterConfigurationReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("kubernetesclusterconfiguration-controller"), opts)
+	return r.SetupWithManager(mgr)
+}
@@ -2,12 +2,17 @@ package pipeline
 
 import (
 	"time"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=pipelines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=pipelines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=nokamoto.github.com,resources=kubernetesclusterconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;watch;create;update;patch;delete
 
 type PipelineReconcilerOptions struct {
 	// PollingInterval is the interval at which the controller will requeue the reconciliation request
@@ -16,3 +21,16 @@ type PipelineReconcilerOptions struct {
 	// If not set, it defaults to 10 seconds.
 	PollingInterval time.Duration
 }
+
+// Setup registers the PipelineQueueReconciler and the PipelineReconciler with the manager.
+func Setup(mgr ctrl.Manager, o boilerplate.Options) error {
+	opts := PipelineReconcilerOptions{
+		PollingInterval: o.PollingInterval.Duration,
+	}
+	queue := NewPipelineQueueReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("pipeline-queue-controller"), opts)
+	if err := queue.SetupWithManager(mgr); err != nil {
+		return err
+	}
+	r := NewPipelineReconciler(mgr.GetClient(), mgr.GetEventRecorderFor("pipeline-controller"), opts)
+	return r.SetupWithManager(mgr)
+}
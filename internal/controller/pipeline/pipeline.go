@@ -3,32 +3,41 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// PipelineReconciler is responsible for running cluster creation pipelines.
-// If the pipeline is in running phase, it will create a KubernetesCluster resource and wait for it to be in the running phase.
+// PipelineReconciler is responsible for running cluster creation and deletion pipelines.
+// If the pipeline is in running phase, it will create a KubernetesCluster resource and wait for it to be in the running phase,
+// or delete the KubernetesClusterConfiguration and KubernetesCluster resources and wait for them to be gone.
 type PipelineReconciler struct {
 	client.Client
-	opts   PipelineReconcilerOptions
-	status *boilerplate.StatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase]
+	recorder record.EventRecorder
+	opts     PipelineReconcilerOptions
+	status   *boilerplate.StatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase]
 }
 
-func NewPipelineReconciler(client client.Client, opts PipelineReconcilerOptions) *PipelineReconciler {
+func NewPipelineReconciler(client client.Client, recorder record.EventRecorder, opts PipelineReconcilerOptions) *PipelineReconciler {
+	recorder = metrics.NewEventRecorder(recorder)
 	return &PipelineReconciler{
-		Client: client,
-		opts:   opts,
-		status: boilerplate.NewStatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase](client),
+		Client:   client,
+		recorder: recorder,
+		opts:     opts,
+		status:   boilerplate.NewStatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase](client, recorder),
 	}
 }
 
-func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling Pipeline")
 	// Fetch the Pipeline instance
@@ -37,11 +46,17 @@ func (r *PipelineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		logger.Error(err, "unable to fetch Pipeline")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx, span := tracing.Start(ctx, pipeline, "PipelineReconciler.Reconcile")
+	defer func() { tracing.End(span, err) }()
 	if pipeline.Status.Phase != v1alpha1.PipelinePhaseRunning {
 		logger.Info("Pipeline is not running. No action required.", "phase", pipeline.Status.Phase)
 		return ctrl.Result{}, nil
 	}
 
+	if pipeline.IsDelete() {
+		return r.reconcileDelete(ctx, req, pipeline)
+	}
+
 	// Steps for creating a KubernetesCluster
 	end, res, err := r.forKubernetesCluster(ctx, req, pipeline)
 	if !end || err != nil {
@@ -89,6 +104,7 @@ func (r *PipelineReconciler) forKubernetesCluster(ctx context.Context, req ctrl.
 	if err := r.Get(ctx, client.ObjectKey{Name: pipeline.Spec.Cluster.Name, Namespace: req.Namespace}, &kubernetesCluster); err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			logger.Error(err, "failed to get KubernetesCluster")
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, "KubernetesClusterGetFailed", "Failed to get KubernetesCluster %s: %v", pipeline.Spec.Cluster.Name, err)
 			return false, ctrl.Result{}, fmt.Errorf("failed to get KubernetesCluster: %w", err)
 		}
 		// KubernetesCluster does not exist, create it
@@ -103,10 +119,13 @@ func (r *PipelineReconciler) forKubernetesCluster(ctx context.Context, req ctrl.
 			},
 			Spec: v1alpha1.KubernetesClusterSpec{},
 		}
+		tracing.Inject(ctx, &kubernetesCluster)
 		if err := r.Create(ctx, &kubernetesCluster); err != nil {
 			logger.Error(err, "failed to create KubernetesCluster")
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, "KubernetesClusterCreateFailed", "Failed to create KubernetesCluster %s: %v", kubernetesCluster.Name, err)
 			return false, ctrl.Result{}, fmt.Errorf("failed to create KubernetesCluster: %w", err)
 		}
+		r.recorder.Eventf(pipeline, corev1.EventTypeNormal, "KubernetesClusterCreated", "Created KubernetesCluster %s", kubernetesCluster.Name)
 		// immediately requeue to poll the status of the KubernetesCluster
 		return false, ctrl.Result{RequeueAfter: r.opts.PollingInterval}, nil
 	}
@@ -127,6 +146,7 @@ func (r *PipelineReconciler) forKubernetesClusterConfiguration(ctx context.Conte
 	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: req.Namespace}, &kubernetesClusterConfiguration); err != nil {
 		if client.IgnoreNotFound(err) != nil {
 			logger.Error(err, "failed to get KubernetesClusterConfiguration")
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, "KubernetesClusterConfigurationGetFailed", "Failed to get KubernetesClusterConfiguration %s: %v", name, err)
 			return false, ctrl.Result{}, fmt.Errorf("failed to get KubernetesClusterConfiguration: %w", err)
 		}
 		// KubernetesClusterConfiguration does not exist, create it
@@ -141,10 +161,13 @@ func (r *PipelineReconciler) forKubernetesClusterConfiguration(ctx context.Conte
 				},
 			},
 		}
+		tracing.Inject(ctx, &kubernetesClusterConfiguration)
 		if err := r.Create(ctx, &kubernetesClusterConfiguration); err != nil {
 			logger.Error(err, "failed to create KubernetesClusterConfiguration")
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, "KubernetesClusterConfigurationCreateFailed", "Failed to create KubernetesClusterConfiguration %s: %v", name, err)
 			return false, ctrl.Result{}, fmt.Errorf("failed to create KubernetesClusterConfiguration: %w", err)
 		}
+		r.recorder.Eventf(pipeline, corev1.EventTypeNormal, "KubernetesClusterConfigurationCreated", "Created KubernetesClusterConfiguration %s", name)
 		logger.Info("KubernetesClusterConfiguration created", "name", kubernetesClusterConfiguration.Name)
 		return false, ctrl.Result{RequeueAfter: r.opts.PollingInterval}, nil
 	}
@@ -157,6 +180,73 @@ func (r *PipelineReconciler) forKubernetesClusterConfiguration(ctx context.Conte
 	return true, ctrl.Result{}, nil
 }
 
+// reconcileDelete deletes the KubernetesClusterConfiguration and then the KubernetesCluster of the pipeline.
+// The pipeline succeeds once both are gone, including a cluster that has already been deleted.
+func (r *PipelineReconciler) reconcileDelete(ctx context.Context, req ctrl.Request, pipeline *v1alpha1.Pipeline) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	name := pipeline.Spec.Cluster.Name
+	if name == "" {
+		if err := r.status.Update(ctx, pipeline, v1alpha1.PipelinePhaseFailed, &metav1.Condition{
+			Type:    string(v1alpha1.PipelineConditionTypeFailed),
+			Status:  metav1.ConditionFalse,
+			Reason:  "ValidationFailed",
+			Message: "KubernetesCluster name is not set in the Pipeline spec.",
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update Pipeline status: %w", err)
+		}
+		logger.Info("KubernetesCluster name is not set in the Pipeline spec. Failing the Pipeline.")
+		return ctrl.Result{}, nil
+	}
+	key := client.ObjectKey{Name: name, Namespace: req.Namespace}
+	// The configuration is deleted first as it is owned by the cluster
+	for _, obj := range []client.Object{&v1alpha1.KubernetesClusterConfiguration{}, &v1alpha1.KubernetesCluster{}} {
+		gone, err := r.deleteObject(ctx, pipeline, key, obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !gone {
+			return ctrl.Result{RequeueAfter: r.opts.PollingInterval}, nil
+		}
+	}
+	if err := r.status.Update(ctx, pipeline, v1alpha1.PipelinePhaseSucceeded, &metav1.Condition{
+		Type:    string(v1alpha1.PipelineConditionTypeReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  "KubernetesClusterDeleted",
+		Message: "KubernetesCluster is deleted and Pipeline has succeeded.",
+	}); err != nil {
+		logger.Error(err, "failed to update Pipeline status")
+		return ctrl.Result{}, fmt.Errorf("failed to update Pipeline status: %w", err)
+	}
+	logger.Info("Pipeline has succeeded")
+	return ctrl.Result{}, nil
+}
+
+// deleteObject deletes the object of the key unless it is already being deleted.
+// It returns true if the object does not exist.
+func (r *PipelineReconciler) deleteObject(ctx context.Context, pipeline *v1alpha1.Pipeline, key client.ObjectKey, obj client.Object) (bool, error) {
+	logger := log.FromContext(ctx)
+	kind := reflect.TypeOf(obj).Elem().Name()
+	if err := r.Get(ctx, key, obj); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "failed to get "+kind)
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, kind+"GetFailed", "Failed to get %s %s: %v", kind, key.Name, err)
+			return false, fmt.Errorf("failed to get %s: %w", kind, err)
+		}
+		return true, nil
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		logger.Info(kind+" is being deleted. Waiting for it to be gone.", "name", key.Name)
+		return false, nil
+	}
+	if err := r.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		logger.Error(err, "failed to delete "+kind)
+		r.recorder.Eventf(pipeline, corev1.EventTypeWarning, kind+"DeleteFailed", "Failed to delete %s %s: %v", kind, key.Name, err)
+		return false, fmt.Errorf("failed to delete %s: %w", kind, err)
+	}
+	r.recorder.Eventf(pipeline, corev1.EventTypeNormal, kind+"Deleted", "Deleted %s %s", kind, key.Name)
+	return false, nil
+}
+
 func (r *PipelineReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("pipeline-controller").
@@ -6,9 +6,14 @@ import (
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/internal/controller/boilerplate"
+	"github.com/nokamoto/kaas-operator-prototype/internal/metrics"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -18,27 +23,39 @@ import (
 // If no pipelines are running, it will start the next one in the queue.
 type PipelineQueueReconciler struct {
 	client.Client
-	opts   PipelineReconcilerOptions
-	status *boilerplate.StatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase]
+	recorder record.EventRecorder
+	opts     PipelineReconcilerOptions
+	status   *boilerplate.StatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase]
 }
 
-func NewPipelineQueueReconciler(client client.Client, opts PipelineReconcilerOptions) *PipelineQueueReconciler {
+func NewPipelineQueueReconciler(client client.Client, recorder record.EventRecorder, opts PipelineReconcilerOptions) *PipelineQueueReconciler {
+	recorder = metrics.NewEventRecorder(recorder)
 	return &PipelineQueueReconciler{
-		Client: client,
-		opts:   opts,
-		status: boilerplate.NewStatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase](client),
+		Client:   client,
+		recorder: recorder,
+		opts:     opts,
+		status:   boilerplate.NewStatusUpdater[*v1alpha1.Pipeline, v1alpha1.PipelinePhase](client, recorder),
 	}
 }
 
-func (r *PipelineQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *PipelineQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling Pipeline")
+	// list all pipelines in the namespace once for both the queue metrics and the queue itself
+	pipelineList := &v1alpha1.PipelineList{}
+	if err := r.List(ctx, pipelineList, client.InNamespace(req.Namespace)); err != nil {
+		logger.Error(err, "unable to list Pipelines")
+		return ctrl.Result{}, fmt.Errorf("failed to list Pipelines: %w", err)
+	}
+	observeQueue(req.Namespace, pipelineList.Items)
 	// Fetch the Pipeline instance
 	pipeline := &v1alpha1.Pipeline{}
 	if err := r.Get(ctx, req.NamespacedName, pipeline); err != nil {
 		logger.Error(err, "unable to fetch Pipeline")
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx, span := tracing.Start(ctx, pipeline, "PipelineQueueReconciler.Reconcile")
+	defer func() { tracing.End(span, err) }()
 	switch pipeline.Status.Phase {
 	case v1alpha1.PipelinePhaseRunning:
 		logger.Info("Pipeline is currently running. Waiting for it to complete.")
@@ -46,8 +63,9 @@ func (r *PipelineQueueReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		logger.Info("Pipeline has completed. No further action required.", "phase", pipeline.Status.Phase)
 	case v1alpha1.PipelinePhasePending:
 		logger.Info("Pipeline is pending. Check if it can be started.")
-		if err := r.reconcile(ctx, pipeline); err != nil {
+		if err := r.reconcile(ctx, pipeline, pipelineList.Items); err != nil {
 			logger.Error(err, "failed to reconcile Pipeline")
+			r.recorder.Eventf(pipeline, corev1.EventTypeWarning, "QueueReconcileFailed", "Failed to check the Pipeline queue: %v", err)
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile Pipeline: %w", err)
 		}
 		// requeue immediately to check the status again
@@ -67,16 +85,12 @@ func (r *PipelineQueueReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
-func (r *PipelineQueueReconciler) reconcile(ctx context.Context, pipeline *v1alpha1.Pipeline) error {
+// reconcile starts the pipeline if none of the pipelines in its namespace is running or queued before it.
+func (r *PipelineQueueReconciler) reconcile(ctx context.Context, pipeline *v1alpha1.Pipeline, pipelines []v1alpha1.Pipeline) error {
 	logger := log.FromContext(ctx)
-	// list all pipelines in the namespace
-	pipelineList := &v1alpha1.PipelineList{}
-	if err := r.List(ctx, pipelineList, client.InNamespace(pipeline.Namespace)); err != nil {
-		return fmt.Errorf("failed to list Pipelines: %w", err)
-	}
 	// check if the pipeline is first in the queue
 	var waitList []*v1alpha1.Pipeline
-	for _, p := range pipelineList.Items {
+	for _, p := range pipelines {
 		logger.Info("Checking Pipeline in queue", "name", p.Name, "phase", p.Status.Phase, "creationTimestamp", p.CreationTimestamp)
 		if p.Name == pipeline.Name {
 			continue
@@ -114,9 +128,23 @@ func (r *PipelineQueueReconciler) reconcile(ctx context.Context, pipeline *v1alp
 	return nil
 }
 
+// observeQueue records the number of pending and running pipelines in the namespace.
+func observeQueue(namespace string, pipelines []v1alpha1.Pipeline) {
+	counts := map[v1alpha1.PipelinePhase]int{}
+	for _, p := range pipelines {
+		counts[p.Status.Phase]++
+	}
+	for _, phase := range []v1alpha1.PipelinePhase{v1alpha1.PipelinePhasePending, v1alpha1.PipelinePhaseRunning} {
+		metrics.PipelinesInPhase.WithLabelValues(namespace, string(phase)).Set(float64(counts[phase]))
+	}
+}
+
+// SetupWithManager registers the reconciler with a single worker regardless of the MaxConcurrentReconciles of the manager.
+// Concurrent reconciles could start two pending pipelines of a namespace at once, since each sees no other running pipeline.
 func (r *PipelineQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("pipeline-queue-controller").
 		For(&v1alpha1.Pipeline{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
 		Complete(r)
 }
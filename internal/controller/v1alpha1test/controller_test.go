@@ -26,6 +26,8 @@ var (
 	testEnv         *envtest.Environment
 	k8sClient       client.Client
 	pollingInterval = 1 * time.Second
+	// eventBufferSize is large enough to hold all events recorded during a single test.
+	eventBufferSize = 16
 )
 
 var _ = BeforeSuite(func() {
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -34,7 +35,7 @@ var _ = Describe("KubernetesClusterReconciler", func() {
 		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
 
 		By("initializing the KubernetesClusterReconciler")
-		kubernetesClusterReconciler = kubernetescluster.NewKubernetesClusterReconciler(k8sClient, kubernetescluster.KubernetesClusterReconcilerOptions{
+		kubernetesClusterReconciler = kubernetescluster.NewKubernetesClusterReconciler(k8sClient, record.NewFakeRecorder(eventBufferSize), kubernetescluster.KubernetesClusterReconcilerOptions{
 			PollingInterval: pollingInterval,
 		})
 	})
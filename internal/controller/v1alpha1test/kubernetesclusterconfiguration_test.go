@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -34,7 +35,7 @@ var _ = Describe("KubernetesClusterConfigurationReconciler", func() {
 		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
 
 		By("initializing the KubernetesClusterConfigurationReconciler")
-		kccReconciler = kccm.NewKubernetesClusterConfigurationReconciler(k8sClient, kccm.KubernetesClusterConfigurationReconcilerOptions{
+		kccReconciler = kccm.NewKubernetesClusterConfigurationReconciler(k8sClient, record.NewFakeRecorder(eventBufferSize), kccm.KubernetesClusterConfigurationReconcilerOptions{
 			PollingInterval: pollingInterval,
 		})
 	})
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -24,6 +25,7 @@ var _ = Describe("PipelineQueueReconciler", func() {
 	}
 
 	var pipelineQueueReconciler *pipeline.PipelineQueueReconciler
+	var recorder *record.FakeRecorder
 
 	BeforeEach(func(ctx context.Context) {
 		ns := &corev1.Namespace{}
@@ -34,7 +36,8 @@ var _ = Describe("PipelineQueueReconciler", func() {
 		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
 
 		By("initializing the PipelineQueueReconciler")
-		pipelineQueueReconciler = pipeline.NewPipelineQueueReconciler(k8sClient, pipeline.PipelineReconcilerOptions{
+		recorder = record.NewFakeRecorder(eventBufferSize)
+		pipelineQueueReconciler = pipeline.NewPipelineQueueReconciler(k8sClient, recorder, pipeline.PipelineReconcilerOptions{
 			PollingInterval: pollingInterval,
 		})
 	})
@@ -67,6 +70,9 @@ var _ = Describe("PipelineQueueReconciler", func() {
 		err = k8sClient.Get(ctx, namespacedName, &got)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(got.Status.Phase).To(Equal(v1alpha1.PipelinePhasePending))
+
+		By("verifying an event is recorded for the phase transition")
+		Expect(recorder.Events).To(Receive(Equal("Normal PipelinePhasePending Phase changed from \"\" to \"Pending\": Pipeline is now pending and waiting to be processed.")))
 	})
 
 	It("should not set running phase if other Pipeline is running", func(ctx context.Context) {
@@ -8,8 +8,10 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -25,6 +27,7 @@ var _ = Describe("PipelineReconciler", func() {
 	}
 
 	var pipelineReconciler *pipeline.PipelineReconciler
+	var recorder *record.FakeRecorder
 
 	BeforeEach(func(ctx context.Context) {
 		ns := &corev1.Namespace{}
@@ -35,7 +38,8 @@ var _ = Describe("PipelineReconciler", func() {
 		Expect(client.IgnoreAlreadyExists(err)).NotTo(HaveOccurred())
 
 		By("initializing the PipelineReconciler")
-		pipelineReconciler = pipeline.NewPipelineReconciler(k8sClient, pipeline.PipelineReconcilerOptions{
+		recorder = record.NewFakeRecorder(eventBufferSize)
+		pipelineReconciler = pipeline.NewPipelineReconciler(k8sClient, recorder, pipeline.PipelineReconcilerOptions{
 			PollingInterval: pollingInterval,
 		})
 	})
@@ -73,6 +77,9 @@ var _ = Describe("PipelineReconciler", func() {
 		err = k8sClient.Get(ctx, namespacedName, &got)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(got.Status.Phase).To(Equal(v1alpha1.PipelinePhaseFailed))
+
+		By("verifying a warning event is recorded for the failure")
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning ValidationFailed")))
 	})
 
 	It("should create a KubernetesCluster resource if not exists", func(ctx context.Context) {
@@ -112,6 +119,9 @@ var _ = Describe("PipelineReconciler", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(cluster.ObjectMeta.Annotations[v1alpha1.KubernetesClusterAnnotationDisplayName]).To(Equal(displayName))
 		Expect(cluster.ObjectMeta.Annotations[v1alpha1.KubernetesClusterAnnotationDescription]).To(Equal(description))
+
+		By("verifying an event is recorded for the KubernetesCluster creation")
+		Expect(recorder.Events).To(Receive(Equal("Normal KubernetesClusterCreated Created KubernetesCluster " + testClusterName)))
 	})
 
 	It("should create a KubernetesClusterConfiguration resource if not exists", func(ctx context.Context) {
@@ -212,4 +222,72 @@ var _ = Describe("PipelineReconciler", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(got.Status.Phase).To(Equal(v1alpha1.PipelinePhaseSucceeded))
 	})
+
+	It("should delete a KubernetesClusterConfiguration and a KubernetesCluster and then succeed", func(ctx context.Context) {
+		got := v1alpha1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+			},
+			Spec: v1alpha1.PipelineSpec{
+				Cluster: v1alpha1.PipelineClusterSpec{
+					Name: testClusterName,
+				},
+				Operation: v1alpha1.PipelineOperationDelete,
+			},
+		}
+		clusterName := types.NamespacedName{
+			Name:      testClusterName,
+			Namespace: testNamespace,
+		}
+
+		By("creating a test Pipeline resource")
+		err := k8sClient.Create(ctx, &got)
+		Expect(err).NotTo(HaveOccurred())
+		updateStatusPL(ctx, &got, v1alpha1.PipelinePhaseRunning)
+
+		By("creating a KubernetesCluster and a KubernetesClusterConfiguration resource")
+		err = k8sClient.Create(ctx, &v1alpha1.KubernetesCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		err = k8sClient.Create(ctx, &v1alpha1.KubernetesClusterConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		By("reconciling the Pipeline resource to delete the KubernetesClusterConfiguration")
+		res, err := pipelineReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: namespacedName,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(pollingInterval))
+		err = k8sClient.Get(ctx, clusterName, &v1alpha1.KubernetesClusterConfiguration{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = k8sClient.Get(ctx, clusterName, &v1alpha1.KubernetesCluster{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorder.Events).To(Receive(Equal("Normal KubernetesClusterConfigurationDeleted Deleted KubernetesClusterConfiguration " + testClusterName)))
+
+		By("reconciling the Pipeline resource to delete the KubernetesCluster")
+		res, err = pipelineReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: namespacedName,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.RequeueAfter).To(Equal(pollingInterval))
+		err = k8sClient.Get(ctx, clusterName, &v1alpha1.KubernetesCluster{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(Equal("Normal KubernetesClusterDeleted Deleted KubernetesCluster " + testClusterName)))
+
+		By("reconciling the Pipeline resource after both are gone")
+		res, err = pipelineReconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: namespacedName,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(res.IsZero()).To(BeTrue())
+
+		By("verifying the Pipeline resource is in succeeded phase")
+		err = k8sClient.Get(ctx, namespacedName, &got)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(got.Status.Phase).To(Equal(v1alpha1.PipelinePhaseSucceeded))
+	})
 })
@@ -1,6 +1,58 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrResourceNotFound is returned when a requested resource is not found.
 var ErrResourceNotFound = errors.New("resource not found")
+
+// ErrAlreadyExists is returned when a resource to create already exists.
+var ErrAlreadyExists = errors.New("resource already exists")
+
+// ErrConflict is returned when a resource has been modified since it was retrieved.
+// The caller may retry with the latest resource.
+var ErrConflict = errors.New("resource modified concurrently")
+
+// ErrFailedPrecondition is returned when the system is not in a state required for the operation.
+// It is matched by errors.Is for every PreconditionError.
+var ErrFailedPrecondition = errors.New("failed precondition")
+
+// PreconditionError describes a precondition that the system does not satisfy.
+type PreconditionError struct {
+	// Type is the kind of the precondition, such as "PROJECT_NAMESPACE".
+	Type string
+	// Subject is the object that violates the precondition, such as "namespaces/project-foo".
+	Subject string
+	// Description explains how the precondition is violated.
+	Description string
+}
+
+func (e *PreconditionError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrFailedPrecondition, e.Description)
+}
+
+func (e *PreconditionError) Is(target error) bool {
+	return target == ErrFailedPrecondition
+}
+
+// ErrQuotaExceeded is returned when an operation would exceed a quota of the project.
+// It is matched by errors.Is for every QuotaError.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// QuotaError describes a quota that an operation would exceed.
+type QuotaError struct {
+	// Subject is the owner of the quota, such as "projects/foo".
+	Subject string
+	// Description explains which limit is exceeded.
+	Description string
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrQuotaExceeded, e.Description)
+}
+
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
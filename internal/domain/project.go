@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ProjectOf returns the project ID of the resource an API request targets, or an empty string if unknown.
+// It looks for a resource name in the parent or name field, then in the name field of the message fields,
+// e.g. `cluster.name` of an update request.
+func ProjectOf(req any) string {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return ""
+	}
+	m := msg.ProtoReflect()
+	for _, field := range []protoreflect.Name{"parent", "name"} {
+		if project := projectOfField(m, field); project != "" {
+			return project
+		}
+	}
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !m.Has(fd) {
+			continue
+		}
+		if project := projectOfField(m.Get(fd).Message(), "name"); project != "" {
+			return project
+		}
+	}
+	return ""
+}
+
+func projectOfField(m protoreflect.Message, name protoreflect.Name) string {
+	fd := m.Descriptor().Fields().ByName(name)
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	segments := strings.Split(m.Get(fd).String(), "/")
+	if len(segments) < 2 || segments[0] != "projects" {
+		return ""
+	}
+	return segments[1]
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ErrInvalidResourceName is returned when a resource name does not match the expected format.
+var ErrInvalidResourceName = errors.New("invalid resource name")
+
+// ProjectNamespacePrefix is the prefix of the namespace that holds the resources of a project.
+// The prefix keeps projects apart from the system namespaces such as kube-system.
+const ProjectNamespacePrefix = "project-"
+
+// ProjectLabel is the label set on the namespace of a project with the project ID as the value.
+// A namespace without the label is not provisioned for the project and is never used by the services.
+const ProjectLabel = "nokamoto.github.com/project"
+
+// ProjectNamespace returns the namespace that holds the resources of the project.
+func ProjectNamespace(project string) string {
+	return ProjectNamespacePrefix + project
+}
+
+// ParseProjectName parses a project resource name of the form `projects/{project}` and returns the project ID.
+func ParseProjectName(name string) (string, error) {
+	ids, err := parseName(name, "projects")
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// ProjectName returns the project resource name of the form `projects/{project}`.
+func ProjectName(project string) string {
+	return "projects/" + project
+}
+
+// ParseQuotaName parses a quota resource name of the form `projects/{project}/quota` and returns the project ID.
+func ParseQuotaName(name string) (string, error) {
+	parent, ok := strings.CutSuffix(name, "/quota")
+	if !ok {
+		return "", fmt.Errorf("%w: %q must be of the form projects/{project}/quota", ErrInvalidResourceName, name)
+	}
+	return ParseProjectName(parent)
+}
+
+// QuotaName returns the quota resource name of the form `projects/{project}/quota`.
+func QuotaName(project string) string {
+	return ProjectName(project) + "/quota"
+}
+
+// ClusterName is a cluster resource name of the form `projects/{project}/clusters/{cluster}`.
+type ClusterName struct {
+	Project string
+	Cluster string
+}
+
+// ParseClusterName parses a cluster resource name.
+func ParseClusterName(name string) (ClusterName, error) {
+	ids, err := parseName(name, "projects", "clusters")
+	if err != nil {
+		return ClusterName{}, err
+	}
+	return ClusterName{Project: ids[0], Cluster: ids[1]}, nil
+}
+
+func (n ClusterName) String() string {
+	return fmt.Sprintf("projects/%s/clusters/%s", n.Project, n.Cluster)
+}
+
+// OperationName is a long-running operation resource name of the form `projects/{project}/operations/{operation}`.
+type OperationName struct {
+	Project   string
+	Operation string
+}
+
+// ParseOperationName parses a long-running operation resource name.
+func ParseOperationName(name string) (OperationName, error) {
+	ids, err := parseName(name, "projects", "operations")
+	if err != nil {
+		return OperationName{}, err
+	}
+	return OperationName{Project: ids[0], Operation: ids[1]}, nil
+}
+
+func (n OperationName) String() string {
+	return fmt.Sprintf("projects/%s/operations/%s", n.Project, n.Operation)
+}
+
+// parseName parses a resource name consisting of the given collection IDs each followed by a resource ID.
+// It returns the resource IDs, which must be DNS-1123 labels.
+// The project ID must additionally fit in a namespace name with ProjectNamespacePrefix.
+func parseName(name string, collections ...string) ([]string, error) {
+	segments := strings.Split(name, "/")
+	if len(segments) != 2*len(collections) {
+		return nil, fmt.Errorf("%w: %q must be of the form %s", ErrInvalidResourceName, name, pattern(collections))
+	}
+	var ids []string
+	for i, collection := range collections {
+		if segments[2*i] != collection {
+			return nil, fmt.Errorf("%w: %q must be of the form %s", ErrInvalidResourceName, name, pattern(collections))
+		}
+		id := segments[2*i+1]
+		if errs := validation.IsDNS1123Label(id); len(errs) > 0 {
+			return nil, fmt.Errorf("%w: %q: %s", ErrInvalidResourceName, id, strings.Join(errs, ", "))
+		}
+		ids = append(ids, id)
+	}
+	if errs := validation.IsDNS1123Label(ProjectNamespace(ids[0])); len(errs) > 0 {
+		return nil, fmt.Errorf("%w: project %q is too long", ErrInvalidResourceName, ids[0])
+	}
+	return ids, nil
+}
+
+func pattern(collections []string) string {
+	var s []string
+	for _, collection := range collections {
+		s = append(s, collection, "{"+strings.TrimSuffix(collection, "s")+"}")
+	}
+	return strings.Join(s, "/")
+}
+
+// ValidateClusterID returns an error if the cluster ID is not a DNS-1123 label starting with a letter.
+// The ID is used as the name of the KubernetesCluster and its dependent resources.
+func ValidateClusterID(id string) error {
+	if errs := validation.IsDNS1123Label(id); len(errs) > 0 {
+		return fmt.Errorf("%w: cluster ID %q: %s", ErrInvalidResourceName, id, strings.Join(errs, ", "))
+	}
+	if id[0] < 'a' || id[0] > 'z' {
+		return fmt.Errorf("%w: cluster ID %q must start with a letter", ErrInvalidResourceName, id)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseClusterName(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    ClusterName
+		wantErr error
+	}{
+		{
+			name: "ok",
+			in:   "projects/p1/clusters/c1",
+			want: ClusterName{Project: "p1", Cluster: "c1"},
+		},
+		{
+			name:    "missing cluster",
+			in:      "projects/p1",
+			wantErr: ErrInvalidResourceName,
+		},
+		{
+			name:    "wrong collection",
+			in:      "projects/p1/operations/o1",
+			wantErr: ErrInvalidResourceName,
+		},
+		{
+			name:    "invalid project",
+			in:      "projects/P_1/clusters/c1",
+			wantErr: ErrInvalidResourceName,
+		},
+		{
+			name:    "project too long for namespace",
+			in:      "projects/" + strings.Repeat("a", 60) + "/clusters/c1",
+			wantErr: ErrInvalidResourceName,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClusterName(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseClusterName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseClusterName() mismatch (-want +got):\n%s", diff)
+			}
+			if err == nil && got.String() != tt.in {
+				t.Errorf("String() = %q, want %q", got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestParseOperationName(t *testing.T) {
+	got, err := ParseOperationName("projects/p1/operations/o1")
+	if err != nil {
+		t.Fatalf("ParseOperationName() error = %v", err)
+	}
+	if diff := cmp.Diff(OperationName{Project: "p1", Operation: "o1"}, got); diff != "" {
+		t.Errorf("ParseOperationName() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := ParseOperationName("operations/o1"); !errors.Is(err, ErrInvalidResourceName) {
+		t.Errorf("ParseOperationName() error = %v, want %v", err, ErrInvalidResourceName)
+	}
+}
+
+func TestParseQuotaName(t *testing.T) {
+	got, err := ParseQuotaName("projects/p1/quota")
+	if err != nil {
+		t.Fatalf("ParseQuotaName() error = %v", err)
+	}
+	if got != "p1" {
+		t.Errorf("ParseQuotaName() = %q, want %q", got, "p1")
+	}
+	if QuotaName(got) != "projects/p1/quota" {
+		t.Errorf("QuotaName() = %q, want %q", QuotaName(got), "projects/p1/quota")
+	}
+	for _, name := range []string{"projects/p1", "projects/P1/quota"} {
+		if _, err := ParseQuotaName(name); !errors.Is(err, ErrInvalidResourceName) {
+			t.Errorf("ParseQuotaName(%q) error = %v, want %v", name, err, ErrInvalidResourceName)
+		}
+	}
+}
+
+func TestValidateClusterID(t *testing.T) {
+	tests := []struct {
+		id      string
+		wantErr error
+	}{
+		{id: "calm-falcon-7c2e"},
+		{id: "a"},
+		{id: strings.Repeat("a", 63)},
+		{id: "", wantErr: ErrInvalidResourceName},
+		{id: strings.Repeat("a", 64), wantErr: ErrInvalidResourceName},
+		{id: "1cluster", wantErr: ErrInvalidResourceName},
+		{id: "cluster-", wantErr: ErrInvalidResourceName},
+		{id: "Cluster", wantErr: ErrInvalidResourceName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if err := ValidateClusterID(tt.id); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateClusterID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
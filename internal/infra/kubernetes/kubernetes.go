@@ -6,7 +6,12 @@ import (
 	"fmt"
 
 	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,11 +19,16 @@ import (
 
 // TypedClient provides typed access to Kubernetes resources.
 //
+// Resources are addressed by project rather than namespace so that a caller can only access
+// the namespace provisioned for the project by EnsureProject. Every access fails if the namespace
+// exists but is not labeled for the project.
 // Get methods return the resource type directly, or ErrResourceNotFound if the resource does not exist.
+// Errors are mapped to the domain errors where possible, such as ErrAlreadyExists and ErrConflict.
 type TypedClient struct {
-	pl  objectClient[*v1alpha1.Pipeline]
-	kc  objectClient[*v1alpha1.KubernetesCluster]
-	kcc objectClient[*v1alpha1.KubernetesClusterConfiguration]
+	client client.Client
+	pl     objectClient[*v1alpha1.Pipeline]
+	kc     objectClient[*v1alpha1.KubernetesCluster]
+	kcc    objectClient[*v1alpha1.KubernetesClusterConfiguration]
 }
 
 func newDefaultRestConfig() (*rest.Config, error) {
@@ -43,6 +53,9 @@ func New() (*TypedClient, error) {
 		return nil, fmt.Errorf("failed to create default rest config: %w", err)
 	}
 	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to add client-go scheme: %w", err)
+	}
 	if err := v1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
 		return nil, fmt.Errorf("failed to add v1alpha1 scheme: %w", err)
 	}
@@ -52,7 +65,12 @@ func New() (*TypedClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
+	return newTypedClient(c), nil
+}
+
+func newTypedClient(c client.Client) *TypedClient {
 	return &TypedClient{
+		client: c,
 		pl: objectClient[*v1alpha1.Pipeline]{
 			client: c,
 			typ:    "Pipeline",
@@ -65,25 +83,150 @@ func New() (*TypedClient, error) {
 			client: c,
 			typ:    "KubernetesClusterConfiguration",
 		},
-	}, nil
+	}
+}
+
+// EnsureProject provisions the namespace of the project if it does not exist.
+// It fails if the namespace exists but is not labeled for the project.
+func (c *TypedClient) EnsureProject(ctx context.Context, project string) error {
+	namespace := domain.ProjectNamespace(project)
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespace,
+			Labels: map[string]string{
+				domain.ProjectLabel: project,
+			},
+		},
+	}
+	err := c.client.Create(ctx, ns)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", namespace, err)
+	}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	return checkProjectLabel(ns, project)
 }
 
-// CreatePipeline creates a new Pipeline resource in the Kubernetes cluster.
-func (c *TypedClient) CreatePipeline(ctx context.Context, pipeline *v1alpha1.Pipeline) error {
+// checkProjectLabel returns a PreconditionError if the namespace is not labeled for the project.
+func checkProjectLabel(ns *corev1.Namespace, project string) error {
+	if ns.Labels[domain.ProjectLabel] != project {
+		return &domain.PreconditionError{
+			Type:        "PROJECT_NAMESPACE",
+			Subject:     "namespaces/" + ns.Name,
+			Description: fmt.Sprintf("namespace %s exists but is not provisioned for project %s", ns.Name, project),
+		}
+	}
+	return nil
+}
+
+// projectNamespace returns the namespace of the project, or an error if the namespace exists but is not labeled for the project.
+// A missing namespace is returned as is since it has no resources of any project.
+func (c *TypedClient) projectNamespace(ctx context.Context, project string) (string, error) {
+	namespace := domain.ProjectNamespace(project)
+	var ns corev1.Namespace
+	if err := c.client.Get(ctx, client.ObjectKey{Name: namespace}, &ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return namespace, nil
+		}
+		return "", fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	if err := checkProjectLabel(&ns, project); err != nil {
+		return "", err
+	}
+	return namespace, nil
+}
+
+// CreatePipeline creates a new Pipeline resource in the namespace of the project.
+func (c *TypedClient) CreatePipeline(ctx context.Context, project string, pipeline *v1alpha1.Pipeline) error {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return err
+	}
+	pipeline.Namespace = namespace
 	return c.pl.create(ctx, pipeline)
 }
 
-// GetPipeline retrieves a Pipeline resource by its name and namespace.
-func (c *TypedClient) GetPipeline(ctx context.Context, name, namespace string) (*v1alpha1.Pipeline, error) {
+// GetPipeline retrieves a Pipeline resource by its name in the namespace of the project.
+func (c *TypedClient) GetPipeline(ctx context.Context, project, name string) (*v1alpha1.Pipeline, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
 	return c.pl.get(ctx, name, namespace)
 }
 
-// GetKubernetesCluster retrieves a KubernetesCluster resource by its name and namespace.
-func (c *TypedClient) GetKubernetesCluster(ctx context.Context, name, namespace string) (*v1alpha1.KubernetesCluster, error) {
+// ListPipelines lists the Pipeline resources with the labels in the namespace of the project.
+func (c *TypedClient) ListPipelines(ctx context.Context, project string, labels map[string]string) ([]v1alpha1.Pipeline, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	var pipelines v1alpha1.PipelineList
+	if err := c.client.List(ctx, &pipelines, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, fmt.Errorf("failed to list Pipeline in namespace %s: %w", namespace, err)
+	}
+	return pipelines.Items, nil
+}
+
+// GetKubernetesCluster retrieves a KubernetesCluster resource by its name in the namespace of the project.
+func (c *TypedClient) GetKubernetesCluster(ctx context.Context, project, name string) (*v1alpha1.KubernetesCluster, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
 	return c.kc.get(ctx, name, namespace)
 }
 
-// GetKubernetesClusterConfiguration retrieves a KubernetesClusterConfiguration resource by its name and namespace.
-func (c *TypedClient) GetKubernetesClusterConfiguration(ctx context.Context, name, namespace string) (*v1alpha1.KubernetesClusterConfiguration, error) {
+// ListKubernetesClusters lists the KubernetesCluster resources in the namespace of the project.
+func (c *TypedClient) ListKubernetesClusters(ctx context.Context, project string) ([]v1alpha1.KubernetesCluster, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	var clusters v1alpha1.KubernetesClusterList
+	if err := c.client.List(ctx, &clusters, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list KubernetesCluster in namespace %s: %w", namespace, err)
+	}
+	return clusters.Items, nil
+}
+
+// UpdateKubernetesCluster updates a KubernetesCluster resource retrieved by GetKubernetesCluster.
+// It fails if the resource has been modified since it was retrieved.
+func (c *TypedClient) UpdateKubernetesCluster(ctx context.Context, project string, kc *v1alpha1.KubernetesCluster) error {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return err
+	}
+	kc.Namespace = namespace
+	return c.kc.update(ctx, kc)
+}
+
+// GetKubernetesClusterConfiguration retrieves a KubernetesClusterConfiguration resource by its name in the namespace of the project.
+func (c *TypedClient) GetKubernetesClusterConfiguration(ctx context.Context, project, name string) (*v1alpha1.KubernetesClusterConfiguration, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
 	return c.kcc.get(ctx, name, namespace)
 }
+
+// ListEvents retrieves the events recorded on the resource identified by its kind and name in the namespace of the project.
+func (c *TypedClient) ListEvents(ctx context.Context, project, kind, name string) ([]corev1.Event, error) {
+	namespace, err := c.projectNamespace(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	var events corev1.EventList
+	err = c.client.List(ctx, &events, client.InNamespace(namespace), client.MatchingFields{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events for %s %s in namespace %s: %w", kind, name, namespace, err)
+	}
+	return events.Items, nil
+}
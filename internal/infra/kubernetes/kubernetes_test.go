@@ -0,0 +1,184 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/internal/infra/namegen"
+	"github.com/nokamoto/kaas-operator-prototype/internal/quota"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/cluster"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testProject = "test-project"
+
+// newFakeClient returns a TypedClient backed by a fake client with the objects.
+func newFakeClient(t *testing.T, objs ...client.Object) *TypedClient {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return newTypedClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build())
+}
+
+func existingCluster() *v1alpha1.KubernetesCluster {
+	return &v1alpha1.KubernetesCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "c1",
+			Namespace: domain.ProjectNamespace(testProject),
+			Annotations: map[string]string{
+				v1alpha1.KubernetesClusterAnnotationDisplayName: "dev",
+			},
+		},
+	}
+}
+
+func TestTypedClient_GetKubernetesCluster(t *testing.T) {
+	c := newFakeClient(t, existingCluster())
+
+	kc, err := c.GetKubernetesCluster(context.Background(), testProject, "c1")
+	if err != nil {
+		t.Fatalf("GetKubernetesCluster() of an existing cluster error = %v", err)
+	}
+	if got := kc.Annotations[v1alpha1.KubernetesClusterAnnotationDisplayName]; got != "dev" {
+		t.Errorf("display name = %q, want %q", got, "dev")
+	}
+
+	kc, err = c.GetKubernetesCluster(context.Background(), testProject, "c2")
+	if !errors.Is(err, domain.ErrResourceNotFound) {
+		t.Errorf("GetKubernetesCluster() of a missing cluster error = %v, want %v", err, domain.ErrResourceNotFound)
+	}
+	if kc != nil {
+		t.Errorf("GetKubernetesCluster() of a missing cluster = %v, want nil", kc)
+	}
+
+	// The project is scoped to its namespace
+	if _, err := c.GetKubernetesCluster(context.Background(), "other-project", "c1"); !errors.Is(err, domain.ErrResourceNotFound) {
+		t.Errorf("GetKubernetesCluster() in another project error = %v, want %v", err, domain.ErrResourceNotFound)
+	}
+}
+
+func TestTypedClient_UpdateKubernetesCluster(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(t, existingCluster())
+
+	kc, err := c.GetKubernetesCluster(ctx, testProject, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := kc.DeepCopy()
+	kc.Annotations[v1alpha1.KubernetesClusterAnnotationDisplayName] = "prod"
+	if err := c.UpdateKubernetesCluster(ctx, testProject, kc); err != nil {
+		t.Fatalf("UpdateKubernetesCluster() error = %v", err)
+	}
+	got, err := c.GetKubernetesCluster(ctx, testProject, "c1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := got.Annotations[v1alpha1.KubernetesClusterAnnotationDisplayName]; name != "prod" {
+		t.Errorf("display name after update = %q, want %q", name, "prod")
+	}
+
+	if err := c.UpdateKubernetesCluster(ctx, testProject, stale); !errors.Is(err, domain.ErrConflict) {
+		t.Errorf("UpdateKubernetesCluster() of a stale cluster error = %v, want %v", err, domain.ErrConflict)
+	}
+}
+
+func TestTypedClient_projectLabel(t *testing.T) {
+	ctx := context.Background()
+	unlabeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: domain.ProjectNamespace(testProject)}}
+	c := newFakeClient(t, unlabeled, existingCluster())
+
+	calls := map[string]func() error{
+		"CreatePipeline": func() error {
+			return c.CreatePipeline(ctx, testProject, &v1alpha1.Pipeline{ObjectMeta: metav1.ObjectMeta{Name: "p1"}})
+		},
+		"GetPipeline": func() error {
+			_, err := c.GetPipeline(ctx, testProject, "p1")
+			return err
+		},
+		"ListPipelines": func() error {
+			_, err := c.ListPipelines(ctx, testProject, nil)
+			return err
+		},
+		"GetKubernetesCluster": func() error {
+			_, err := c.GetKubernetesCluster(ctx, testProject, "c1")
+			return err
+		},
+		"ListKubernetesClusters": func() error {
+			_, err := c.ListKubernetesClusters(ctx, testProject)
+			return err
+		},
+		"UpdateKubernetesCluster": func() error {
+			return c.UpdateKubernetesCluster(ctx, testProject, existingCluster())
+		},
+		"GetKubernetesClusterConfiguration": func() error {
+			_, err := c.GetKubernetesClusterConfiguration(ctx, testProject, "c1")
+			return err
+		},
+		"ListEvents": func() error {
+			_, err := c.ListEvents(ctx, testProject, "Pipeline", "p1")
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err := call(); !errors.Is(err, domain.ErrFailedPrecondition) {
+				t.Errorf("%s() in an unlabeled namespace error = %v, want %v", name, err, domain.ErrFailedPrecondition)
+			}
+		})
+	}
+
+	if err := c.EnsureProject(ctx, "other-project"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListKubernetesClusters(ctx, "other-project"); err != nil {
+		t.Errorf("ListKubernetesClusters() in a labeled namespace error = %v", err)
+	}
+}
+
+// TestClusterService runs the ClusterService against the fake client rather than mocks of the client.
+func TestClusterService(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(t, existingCluster())
+	service := cluster.New(c, &namegen.Namegen{}, quota.New(c, quota.Limits{}))
+
+	_, err := service.CreateCluster(ctx, connect.NewRequest(&apiv1alpha1.CreateClusterRequest{
+		Parent:    domain.ProjectName(testProject),
+		Cluster:   &apiv1alpha1.Cluster{},
+		ClusterId: "c1",
+	}))
+	if connect.CodeOf(err) != connect.CodeAlreadyExists {
+		t.Errorf("CreateCluster() with an existing cluster ID code = %v, want %v", connect.CodeOf(err), connect.CodeAlreadyExists)
+	}
+
+	res, err := service.UpdateCluster(ctx, connect.NewRequest(&apiv1alpha1.UpdateClusterRequest{
+		Cluster:    &apiv1alpha1.Cluster{Name: "projects/test-project/clusters/c1", Description: "updated"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+	}))
+	if err != nil {
+		t.Fatalf("UpdateCluster() error = %v", err)
+	}
+	var updated apiv1alpha1.Cluster
+	if err := res.Msg.GetResponse().UnmarshalTo(&updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.GetDisplayName() != "dev" || updated.GetDescription() != "updated" {
+		t.Errorf("UpdateCluster() = %v, want the display name dev and the description updated", &updated)
+	}
+}
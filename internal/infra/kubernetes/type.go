@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -15,20 +17,41 @@ type objectClient[A client.Object] struct {
 }
 
 func (c *objectClient[A]) create(ctx context.Context, obj A) error {
-	if err := c.client.Create(ctx, obj); err != nil {
+	err := c.client.Create(ctx, obj)
+	if apierrors.IsAlreadyExists(err) {
+		return errors.Join(domain.ErrAlreadyExists, fmt.Errorf("%s %s already exists in namespace %s", c.typ, obj.GetName(), obj.GetNamespace()))
+	}
+	if err != nil {
 		return fmt.Errorf("failed to create %s: %w", c.typ, err)
 	}
 	return nil
 }
 
+func (c *objectClient[A]) update(ctx context.Context, obj A) error {
+	err := c.client.Update(ctx, obj)
+	if apierrors.IsConflict(err) {
+		return errors.Join(domain.ErrConflict, fmt.Errorf("%s %s in namespace %s has been modified", c.typ, obj.GetName(), obj.GetNamespace()))
+	}
+	if apierrors.IsNotFound(err) {
+		return errors.Join(domain.ErrResourceNotFound, fmt.Errorf("%s %s not found in namespace %s", c.typ, obj.GetName(), obj.GetNamespace()))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s %s in namespace %s: %w", c.typ, obj.GetName(), obj.GetNamespace(), err)
+	}
+	return nil
+}
+
 func (c *objectClient[A]) get(ctx context.Context, name, namespace string) (A, error) {
-	var obj A
+	// A is a pointer type, so allocate the object it points to for the client to decode into
+	obj := reflect.New(reflect.TypeFor[A]().Elem()).Interface().(A)
 	err := c.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj)
 	if client.IgnoreNotFound(err) != nil {
-		return obj, fmt.Errorf("failed to get %s %s in namespace %s: %w", c.typ, name, namespace, err)
+		var zero A
+		return zero, fmt.Errorf("failed to get %s %s in namespace %s: %w", c.typ, name, namespace, err)
 	}
 	if err != nil {
-		return obj, errors.Join(domain.ErrResourceNotFound, fmt.Errorf("%s %s not found in namespace %s", c.typ, name, namespace))
+		var zero A
+		return zero, errors.Join(domain.ErrResourceNotFound, fmt.Errorf("%s %s not found in namespace %s", c.typ, name, namespace))
 	}
 	return obj, nil
 }
@@ -2,10 +2,25 @@ package namegen
 
 import (
 	"fmt"
+	"math/rand/v2"
 
 	"github.com/google/uuid"
 )
 
+var adjectives = []string{
+	"amber", "bold", "brave", "bright", "calm", "clever", "cosmic", "crisp",
+	"eager", "fancy", "gentle", "glad", "golden", "happy", "humble", "jolly",
+	"keen", "lively", "lucky", "mellow", "misty", "noble", "proud", "quick",
+	"quiet", "rapid", "silver", "sleek", "steady", "sunny", "swift", "witty",
+}
+
+var nouns = []string{
+	"badger", "beacon", "breeze", "canyon", "comet", "coral", "falcon", "fern",
+	"glacier", "harbor", "heron", "island", "lagoon", "lynx", "maple", "meadow",
+	"nebula", "otter", "panda", "pebble", "pine", "quartz", "raven", "river",
+	"sparrow", "summit", "thicket", "tiger", "tundra", "valley", "willow", "zephyr",
+}
+
 type Namegen struct{}
 
 // New generates a unique name for a resource.
@@ -13,3 +28,9 @@ type Namegen struct{}
 func (*Namegen) New(format string, v ...any) string {
 	return fmt.Sprintf("%s-%s", fmt.Sprintf(format, v...), uuid.NewString())
 }
+
+// NewID generates a short human-readable ID such as "calm-falcon-7c2e".
+// It is not guaranteed to be unique, so the caller must check it for collisions.
+func (*Namegen) NewID() string {
+	return fmt.Sprintf("%s-%s-%04x", adjectives[rand.IntN(len(adjectives))], nouns[rand.IntN(len(nouns))], rand.IntN(1<<16))
+}
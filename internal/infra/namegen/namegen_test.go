@@ -0,0 +1,17 @@
+package namegen
+
+import (
+	"testing"
+
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+)
+
+func TestNamegen_NewID(t *testing.T) {
+	var n Namegen
+	for range 100 {
+		id := n.NewID()
+		if err := domain.ValidateClusterID(id); err != nil {
+			t.Fatalf("NewID() = %q is not a valid cluster ID: %v", id, err)
+		}
+	}
+}
@@ -16,6 +16,8 @@ type runtime interface {
 
 // ClusterCreateRequest is the request for creating a KaaS cluster.
 type ClusterCreateRequest struct {
+	Project     string `json:"project" jsonschema:"required. The ID of the project to create the cluster in."`
+	ClusterID   string `json:"cluster_id" jsonschema:"optional. The ID of the cluster: lowercase letters, digits and '-', starting with a letter. Generated if empty."`
 	DisplayName string `json:"display_name" jsonschema:"optional. The display name of the cluster."`
 	Description string `json:"description" jsonschema:"optional. The description of the cluster."`
 }
@@ -49,6 +51,8 @@ func (c *CreateTool) Handler(
 			DisplayName: params.Arguments.DisplayName,
 			Description: params.Arguments.Description,
 		},
+		Parent:    "projects/" + params.Arguments.Project,
+		ClusterId: params.Arguments.ClusterID,
 	}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cluster: %w", err)
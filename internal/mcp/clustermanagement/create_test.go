@@ -25,7 +25,8 @@ func (m *mockRuntime) ClusterService() v1alpha1connect.ClusterServiceClient {
 func TestCreateTool_Handler(t *testing.T) {
 	testDisplayName := "test-cluster"
 	testDescription := "This is a test cluster."
-	testOperationName := "test-operation"
+	testProject := "test-project"
+	testOperationName := "projects/test-project/operations/test-operation"
 	type testcase struct {
 		name    string
 		request ClusterCreateRequest
@@ -37,6 +38,7 @@ func TestCreateTool_Handler(t *testing.T) {
 		{
 			name: "cluster creation successfully started",
 			request: ClusterCreateRequest{
+				Project:     testProject,
 				DisplayName: testDisplayName,
 				Description: testDescription,
 			},
@@ -46,6 +48,7 @@ func TestCreateTool_Handler(t *testing.T) {
 						DisplayName: testDisplayName,
 						Description: testDescription,
 					},
+					Parent: "projects/" + testProject,
 				})).Return(connect.NewResponse(&v1alpha1.LongRunningOperation{
 					Name: testOperationName,
 				}), nil)
@@ -53,7 +56,7 @@ func TestCreateTool_Handler(t *testing.T) {
 			want: &mcp.CallToolResultFor[any]{
 				Content: []mcp.Content{
 					&mcp.TextContent{
-						Text: "Cluster creation successfully started at operation `test-operation`.",
+						Text: "Cluster creation successfully started at operation `projects/test-project/operations/test-operation`.",
 					},
 				},
 			},
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// NewInterceptor returns a Connect interceptor that records the count and the latency of unary RPCs.
+func NewInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+			procedure := req.Spec().Procedure
+			code := "ok"
+			if err != nil {
+				code = connect.CodeOf(err).String()
+			}
+			APIRequests.WithLabelValues(procedure, code).Inc()
+			APIRequestDuration.WithLabelValues(procedure).Observe(time.Since(start).Seconds())
+			return res, err
+		}
+	}
+}
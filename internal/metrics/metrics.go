@@ -0,0 +1,68 @@
+// Package metrics defines the custom Prometheus metrics of the controllers and the API server.
+// All metrics are registered on the controller-runtime metrics registry.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kaas"
+
+var (
+	// PipelinesInPhase is the number of pipelines in each queue phase (Pending or Running) per namespace.
+	PipelinesInPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pipelines",
+		Help:      "Number of pipelines in the queue by namespace and phase.",
+	}, []string{"namespace", "phase"})
+
+	// PhaseDuration is the time spent by a resource in a phase before transitioning to the next one.
+	PhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "phase_duration_seconds",
+		Help:      "Time spent in a phase by kind and phase.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"kind", "phase"})
+
+	// ClusterCreationDuration is the end-to-end latency from the creation of a pipeline to its completion.
+	ClusterCreationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "cluster_creation_duration_seconds",
+		Help:      "End-to-end cluster creation latency by result.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"result"})
+
+	// StepFailures is the number of failed reconciliation steps by kind and reason.
+	StepFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "step_failures_total",
+		Help:      "Number of failed reconciliation steps by kind and reason.",
+	}, []string{"kind", "reason"})
+
+	// APIRequests is the number of RPCs handled by the API server by procedure and code.
+	APIRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_requests_total",
+		Help:      "Number of RPCs handled by the API server by procedure and code.",
+	}, []string{"procedure", "code"})
+
+	// APIRequestDuration is the latency of RPCs handled by the API server by procedure.
+	APIRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "api_request_duration_seconds",
+		Help:      "Latency of RPCs handled by the API server by procedure.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"procedure"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		PipelinesInPhase,
+		PhaseDuration,
+		ClusterCreationDuration,
+		StepFailures,
+		APIRequests,
+		APIRequestDuration,
+	)
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestNewInterceptor(t *testing.T) {
+	type testcase struct {
+		name      string
+		procedure string
+		err       error
+		code      string
+	}
+	tests := []testcase{
+		{
+			name:      "count ok if the handler succeeds",
+			procedure: "/test.Service/Succeed",
+			code:      "ok",
+		},
+		{
+			name:      "count error code if the handler fails",
+			procedure: "/test.Service/Fail",
+			err:       connect.NewError(connect.CodeNotFound, errors.New("not found")),
+			code:      connect.CodeNotFound.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+				return connect.NewResponse(&emptypb.Empty{}), tt.err
+			}
+			req := &testRequest{Request: connect.NewRequest(&emptypb.Empty{}), procedure: tt.procedure}
+			_, _ = NewInterceptor()(next)(context.TODO(), req)
+			if got := testutil.ToFloat64(APIRequests.WithLabelValues(tt.procedure, tt.code)); got != 1 {
+				t.Errorf("APIRequests = %v, want 1", got)
+			}
+		})
+	}
+}
+
+type testRequest struct {
+	*connect.Request[emptypb.Empty]
+	procedure string
+}
+
+func (r *testRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
+
+func TestEventRecorder(t *testing.T) {
+	recorder := NewEventRecorder(record.NewFakeRecorder(2))
+	obj := &v1alpha1.Pipeline{}
+	recorder.Eventf(obj, corev1.EventTypeNormal, "Created", "created %s", "test")
+	recorder.Eventf(obj, corev1.EventTypeWarning, "CreateFailed", "failed to create %s", "test")
+	if got := testutil.ToFloat64(StepFailures.WithLabelValues("Pipeline", "Created")); got != 0 {
+		t.Errorf("StepFailures for normal events = %v, want 0", got)
+	}
+	if got := testutil.ToFloat64(StepFailures.WithLabelValues("Pipeline", "CreateFailed")); got != 1 {
+		t.Errorf("StepFailures for warning events = %v, want 1", got)
+	}
+}
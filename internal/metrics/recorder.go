@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// EventRecorder wraps a record.EventRecorder to count warning events as step failures.
+type EventRecorder struct {
+	record.EventRecorder
+}
+
+// NewEventRecorder returns an EventRecorder that delegates to the given recorder.
+func NewEventRecorder(recorder record.EventRecorder) *EventRecorder {
+	return &EventRecorder{EventRecorder: recorder}
+}
+
+func (r *EventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.count(object, eventtype, reason)
+	r.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+func (r *EventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...any) {
+	r.count(object, eventtype, reason)
+	r.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+}
+
+func (r *EventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...any) {
+	r.count(object, eventtype, reason)
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+}
+
+func (r *EventRecorder) count(object runtime.Object, eventtype, reason string) {
+	if eventtype != corev1.EventTypeWarning {
+		return
+	}
+	StepFailures.WithLabelValues(Kind(object), reason).Inc()
+}
+
+// Kind returns the kind of the given object.
+// Typed objects fetched through a client usually have an empty TypeMeta, so it falls back to the Go type name.
+func Kind(object runtime.Object) string {
+	if kind := object.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(object)).Type().Name()
+}
@@ -16,7 +16,6 @@ import (
 	connect "connectrpc.com/connect"
 	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	gomock "go.uber.org/mock/gomock"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // MockClusterServiceClient is a mock of ClusterServiceClient interface.
@@ -89,7 +88,7 @@ func (mr *MockClusterServiceClientMockRecorder) GetCluster(arg0, arg1 any) *gomo
 }
 
 // ListClusters mocks base method.
-func (m *MockClusterServiceClient) ListClusters(arg0 context.Context, arg1 *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
+func (m *MockClusterServiceClient) ListClusters(arg0 context.Context, arg1 *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListClusters", arg0, arg1)
 	ret0, _ := ret[0].(*connect.Response[v1alpha1.ListClustersResponse])
@@ -102,3 +101,18 @@ func (mr *MockClusterServiceClientMockRecorder) ListClusters(arg0, arg1 any) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusters", reflect.TypeOf((*MockClusterServiceClient)(nil).ListClusters), arg0, arg1)
 }
+
+// UpdateCluster mocks base method.
+func (m *MockClusterServiceClient) UpdateCluster(arg0 context.Context, arg1 *connect.Request[v1alpha1.UpdateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCluster", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[v1alpha1.LongRunningOperation])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCluster indicates an expected call of UpdateCluster.
+func (mr *MockClusterServiceClientMockRecorder) UpdateCluster(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCluster", reflect.TypeOf((*MockClusterServiceClient)(nil).UpdateCluster), arg0, arg1)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nokamoto/kaas-operator-prototype/internal/quota (interfaces: client)
+//
+// Generated by this command:
+//
+//	mockgen -package quota -destination mock_quota_test.go . client
+//
+
+// Package quota is a generated GoMock package.
+package quota
+
+import (
+	context "context"
+	reflect "reflect"
+
+	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockclient is a mock of client interface.
+type Mockclient struct {
+	ctrl     *gomock.Controller
+	recorder *MockclientMockRecorder
+	isgomock struct{}
+}
+
+// MockclientMockRecorder is the mock recorder for Mockclient.
+type MockclientMockRecorder struct {
+	mock *Mockclient
+}
+
+// NewMockclient creates a new mock instance.
+func NewMockclient(ctrl *gomock.Controller) *Mockclient {
+	mock := &Mockclient{ctrl: ctrl}
+	mock.recorder = &MockclientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockclient) EXPECT() *MockclientMockRecorder {
+	return m.recorder
+}
+
+// ListKubernetesClusters mocks base method.
+func (m *Mockclient) ListKubernetesClusters(ctx context.Context, project string) ([]v1alpha1.KubernetesCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKubernetesClusters", ctx, project)
+	ret0, _ := ret[0].([]v1alpha1.KubernetesCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKubernetesClusters indicates an expected call of ListKubernetesClusters.
+func (mr *MockclientMockRecorder) ListKubernetesClusters(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKubernetesClusters", reflect.TypeOf((*Mockclient)(nil).ListKubernetesClusters), ctx, project)
+}
+
+// ListPipelines mocks base method.
+func (m *Mockclient) ListPipelines(ctx context.Context, project string, labels map[string]string) ([]v1alpha1.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPipelines", ctx, project, labels)
+	ret0, _ := ret[0].([]v1alpha1.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPipelines indicates an expected call of ListPipelines.
+func (mr *MockclientMockRecorder) ListPipelines(ctx, project, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelines", reflect.TypeOf((*Mockclient)(nil).ListPipelines), ctx, project, labels)
+}
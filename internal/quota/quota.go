@@ -0,0 +1,102 @@
+//go:generate mockgen -package quota -destination mock_quota_test.go . client
+
+// Package quota enforces the limits of the resources a project can use.
+package quota
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+)
+
+type client interface {
+	ListPipelines(ctx context.Context, project string, labels map[string]string) ([]v1alpha1.Pipeline, error)
+	ListKubernetesClusters(ctx context.Context, project string) ([]v1alpha1.KubernetesCluster, error)
+}
+
+// Limits are the quota limits applied to every project. A limit of 0 means unlimited.
+type Limits struct {
+	// MaxClusters is the maximum number of clusters in a project, including the ones being created.
+	MaxClusters int
+	// MaxPendingOperations is the maximum number of pipelines in progress in a project.
+	MaxPendingOperations int
+}
+
+// Usage is the current usage of the resources limited by the quota.
+type Usage struct {
+	Clusters          int
+	PendingOperations int
+}
+
+// Quota computes the usage of a project from its resources and checks it against the limits.
+type Quota struct {
+	client client
+	limits Limits
+}
+
+func New(client client, limits Limits) *Quota {
+	return &Quota{
+		client: client,
+		limits: limits,
+	}
+}
+
+// Limits returns the limits applied to every project.
+func (q *Quota) Limits() Limits {
+	return q.limits
+}
+
+// Usage returns the current usage of the project.
+// A cluster counts as soon as a pipeline that is not failed is creating it.
+func (q *Quota) Usage(ctx context.Context, project string) (Usage, error) {
+	clusters, err := q.client.ListKubernetesClusters(ctx, project)
+	if err != nil {
+		return Usage{}, err
+	}
+	pipelines, err := q.client.ListPipelines(ctx, project, nil)
+	if err != nil {
+		return Usage{}, err
+	}
+	names := map[string]bool{}
+	for _, kc := range clusters {
+		names[kc.Name] = true
+	}
+	var usage Usage
+	for _, p := range pipelines {
+		switch p.Status.Phase {
+		case v1alpha1.PipelinePhaseSucceeded, v1alpha1.PipelinePhaseFailed:
+			continue
+		}
+		usage.PendingOperations++
+		names[p.Spec.Cluster.Name] = true
+	}
+	usage.Clusters = len(names)
+	return usage, nil
+}
+
+// CheckCreateCluster returns a QuotaError if creating another cluster would exceed the limits of the project.
+// The check is best-effort: concurrent requests may exceed the limits by the number of requests in flight.
+func (q *Quota) CheckCreateCluster(ctx context.Context, project string) error {
+	if q.limits.MaxClusters <= 0 && q.limits.MaxPendingOperations <= 0 {
+		return nil
+	}
+	usage, err := q.Usage(ctx, project)
+	if err != nil {
+		return err
+	}
+	if q.limits.MaxClusters > 0 && usage.Clusters >= q.limits.MaxClusters {
+		return &domain.QuotaError{
+			Subject:     domain.ProjectName(project),
+			Description: fmt.Sprintf("project %s already has %d clusters, the maximum is %d", project, usage.Clusters, q.limits.MaxClusters),
+		}
+	}
+	if q.limits.MaxPendingOperations > 0 && usage.PendingOperations >= q.limits.MaxPendingOperations {
+		return &domain.QuotaError{
+			Subject:     domain.ProjectName(project),
+			Description: fmt.Sprintf("project %s already has %d operations in progress, the maximum is %d", project, usage.PendingOperations, q.limits.MaxPendingOperations),
+		}
+	}
+	return nil
+}
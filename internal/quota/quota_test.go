@@ -0,0 +1,116 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func cluster(name string) v1alpha1.KubernetesCluster {
+	return v1alpha1.KubernetesCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func pipeline(cluster string, phase v1alpha1.PipelinePhase) v1alpha1.Pipeline {
+	return v1alpha1.Pipeline{
+		Spec:   v1alpha1.PipelineSpec{Cluster: v1alpha1.PipelineClusterSpec{Name: cluster}},
+		Status: v1alpha1.PipelineStatus{Phase: phase},
+	}
+}
+
+func TestQuota_Usage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := NewMockclient(ctrl)
+	client.EXPECT().ListKubernetesClusters(gomock.Any(), "p1").Return([]v1alpha1.KubernetesCluster{
+		cluster("c1"),
+		cluster("c2"),
+	}, nil)
+	client.EXPECT().ListPipelines(gomock.Any(), "p1", nil).Return([]v1alpha1.Pipeline{
+		pipeline("c1", v1alpha1.PipelinePhaseSucceeded),
+		pipeline("c2", v1alpha1.PipelinePhaseRunning),
+		pipeline("c3", v1alpha1.PipelinePhasePending),
+		pipeline("c4", v1alpha1.PipelinePhaseFailed),
+	}, nil)
+
+	got, err := New(client, Limits{}).Usage(context.Background(), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Usage{Clusters: 3, PendingOperations: 2}, got); diff != "" {
+		t.Errorf("Usage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQuota_CheckCreateCluster(t *testing.T) {
+	type testcase struct {
+		name    string
+		limits  Limits
+		mock    func(*Mockclient)
+		wantErr error
+	}
+	usage := func(clusters []v1alpha1.KubernetesCluster, pipelines []v1alpha1.Pipeline) func(*Mockclient) {
+		return func(m *Mockclient) {
+			m.EXPECT().ListKubernetesClusters(gomock.Any(), "p1").Return(clusters, nil)
+			m.EXPECT().ListPipelines(gomock.Any(), "p1", nil).Return(pipelines, nil)
+		}
+	}
+	tests := []testcase{
+		{
+			name: "unlimited",
+		},
+		{
+			name:   "within the limits",
+			limits: Limits{MaxClusters: 2, MaxPendingOperations: 2},
+			mock: usage(
+				[]v1alpha1.KubernetesCluster{cluster("c1")},
+				[]v1alpha1.Pipeline{pipeline("c1", v1alpha1.PipelinePhaseSucceeded)},
+			),
+		},
+		{
+			name:   "too many clusters",
+			limits: Limits{MaxClusters: 2},
+			mock: usage(
+				[]v1alpha1.KubernetesCluster{cluster("c1")},
+				[]v1alpha1.Pipeline{pipeline("c2", v1alpha1.PipelinePhaseRunning)},
+			),
+			wantErr: domain.ErrQuotaExceeded,
+		},
+		{
+			name:   "too many pending operations",
+			limits: Limits{MaxPendingOperations: 1},
+			mock: usage(
+				nil,
+				[]v1alpha1.Pipeline{pipeline("c1", v1alpha1.PipelinePhasePending)},
+			),
+			wantErr: domain.ErrQuotaExceeded,
+		},
+		{
+			name:   "list error",
+			limits: Limits{MaxClusters: 1},
+			mock: func(m *Mockclient) {
+				m.EXPECT().ListKubernetesClusters(gomock.Any(), "p1").Return(nil, errTest)
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			if tt.mock != nil {
+				tt.mock(client)
+			}
+			err := New(client, tt.limits).CheckCreateCluster(context.Background(), "p1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckCreateCluster() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+var errTest = errors.New("test")
@@ -0,0 +1,163 @@
+// Package ratelimit limits the rate of API requests per caller.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	"golang.org/x/time/rate"
+)
+
+// idleTimeout is how long the limiter of a caller is kept after its last request.
+// A limiter idle for longer than it takes to refill its burst is equivalent to a new one.
+const idleTimeout = time.Minute
+
+// Limiter is a token bucket rate limiter per caller.
+type Limiter struct {
+	limit rate.Limit
+	burst int
+	now   func() time.Time
+
+	mu        sync.Mutex
+	callers   map[string]*caller
+	lastSweep time.Time
+}
+
+type caller struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// New returns a Limiter that allows each caller rps requests per second with bursts of up to burst requests.
+func New(rps float64, burst int) *Limiter {
+	return &Limiter{
+		limit:   rate.Limit(rps),
+		burst:   burst,
+		now:     time.Now,
+		callers: map[string]*caller{},
+	}
+}
+
+// Reserve takes a token of the caller. It returns 0 if the request is allowed,
+// or the duration after which the caller may retry otherwise.
+func (l *Limiter) Reserve(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	r := l.caller(key, now).limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return idleTimeout
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return delay
+	}
+	return 0
+}
+
+// Delay returns 0 if the caller has a token, or the duration after which it will have one otherwise.
+// Unlike Reserve, it does not take the token.
+func (l *Limiter) Delay(key string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	tokens := l.caller(key, now).limiter.TokensAt(now)
+	if tokens >= 1 {
+		return 0
+	}
+	if l.limit <= 0 {
+		return idleTimeout
+	}
+	return time.Duration((1 - tokens) / float64(l.limit) * float64(time.Second))
+}
+
+// caller returns the limiter of the caller seen at now, creating it if the caller is new.
+// It must be called with the lock held.
+func (l *Limiter) caller(key string, now time.Time) *caller {
+	l.sweep(now)
+	c, ok := l.callers[key]
+	if !ok {
+		c = &caller{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.callers[key] = c
+	}
+	c.lastSeen = now
+	return c
+}
+
+// sweep forgets the callers idle for longer than the idle timeout to bound the memory.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleTimeout {
+		return
+	}
+	l.lastSweep = now
+	for key, c := range l.callers {
+		if now.Sub(c.lastSeen) > idleTimeout {
+			delete(l.callers, key)
+		}
+	}
+}
+
+// callerKey identifies the caller by its authentication method and subject, or by its address if unauthenticated.
+func callerKey(ctx context.Context, req connect.AnyRequest) string {
+	if id, ok := authn.IdentityFrom(ctx); ok {
+		return "subject:" + id.Key()
+	}
+	return addressKey(req)
+}
+
+// addressKey identifies the caller by the host of its address.
+func addressKey(req connect.AnyRequest) string {
+	addr := req.Peer().Addr
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return "addr:" + addr
+}
+
+// NewInterceptor returns an interceptor that rejects the requests of a caller exceeding the rate limit
+// with CodeResourceExhausted and a RetryInfo detail.
+// It should be placed after the authn interceptor so that callers are identified by their subject.
+func NewInterceptor(limiter *Limiter) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			key := callerKey(ctx, req)
+			if delay := limiter.Reserve(key); delay > 0 {
+				return nil, apierror.RateLimited(fmt.Errorf("rate limit exceeded for %s, retry after %s", key, delay.Round(time.Millisecond)), delay)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// NewUnauthenticatedInterceptor returns an interceptor that limits the rate of failed authentications per address.
+// It must be placed before the authn interceptor, which is otherwise not reached by the rejected requests.
+// Only the requests failing with CodeUnauthenticated take a token, so that the callers authenticated from
+// a shared address, such as behind a proxy, are still limited per subject by NewInterceptor.
+// Once the address has no token, every request from it is rejected with CodeResourceExhausted until it refills.
+func NewUnauthenticatedInterceptor(limiter *Limiter) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			key := addressKey(req)
+			if delay := limiter.Delay(key); delay > 0 {
+				return nil, apierror.RateLimited(fmt.Errorf("rate limit of failed authentications exceeded for %s, retry after %s", key, delay.Round(time.Millisecond)), delay)
+			}
+			res, err := next(ctx, req)
+			if connect.CodeOf(err) == connect.CodeUnauthenticated {
+				limiter.Reserve(key)
+			}
+			return res, err
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/authn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestLimiter_Reserve(t *testing.T) {
+	now := time.Now()
+	l := New(1, 2)
+	l.now = func() time.Time { return now }
+
+	for i := range 2 {
+		if delay := l.Reserve("alice"); delay != 0 {
+			t.Fatalf("request %d: Reserve() = %v, want 0 within the burst", i, delay)
+		}
+	}
+	if delay := l.Reserve("alice"); delay != time.Second {
+		t.Errorf("Reserve() = %v, want %v after the burst", delay, time.Second)
+	}
+	if delay := l.Reserve("bob"); delay != 0 {
+		t.Errorf("Reserve() = %v, want 0 for another caller", delay)
+	}
+
+	now = now.Add(time.Second)
+	if delay := l.Reserve("alice"); delay != 0 {
+		t.Errorf("Reserve() = %v, want 0 after the refill", delay)
+	}
+
+	now = now.Add(2 * idleTimeout)
+	l.Reserve("bob")
+	if _, ok := l.callers["alice"]; ok {
+		t.Errorf("idle caller is not forgotten")
+	}
+}
+
+func TestLimiter_Delay(t *testing.T) {
+	now := time.Now()
+	l := New(1, 1)
+	l.now = func() time.Time { return now }
+
+	for range 2 {
+		if delay := l.Delay("alice"); delay != 0 {
+			t.Fatalf("Delay() = %v, want 0 without taking the token", delay)
+		}
+	}
+	l.Reserve("alice")
+	if delay := l.Delay("alice"); delay != time.Second {
+		t.Errorf("Delay() = %v, want %v after the token is taken", delay, time.Second)
+	}
+}
+
+func TestNewInterceptor(t *testing.T) {
+	interceptor := NewInterceptor(New(1, 1))
+	next := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	call := func(id *authn.Identity) error {
+		ctx := authn.WithIdentity(context.Background(), id)
+		_, err := interceptor(next)(ctx, connect.NewRequest(&emptypb.Empty{}))
+		return err
+	}
+	alice := &authn.Identity{Subject: "alice", Method: authn.MethodJWT}
+	if err := call(alice); err != nil {
+		t.Fatalf("first call failed: %v", err)
+	}
+	if code := connect.CodeOf(call(alice)); code != connect.CodeResourceExhausted {
+		t.Errorf("second call code = %v, want %v", code, connect.CodeResourceExhausted)
+	}
+	// The same subject authenticated by another method is another caller
+	if err := call(&authn.Identity{Subject: "alice", Method: authn.MethodMTLS}); err != nil {
+		t.Errorf("call with a client certificate failed: %v", err)
+	}
+}
+
+func TestNewUnauthenticatedInterceptor(t *testing.T) {
+	interceptor := NewUnauthenticatedInterceptor(New(1, 1))
+	var nextErr error
+	next := func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		if nextErr != nil {
+			return nil, nextErr
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	call := func() error {
+		_, err := interceptor(next)(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+		return err
+	}
+	// Authenticated requests do not take the tokens of the address
+	for range 2 {
+		if err := call(); err != nil {
+			t.Fatalf("authenticated call failed: %v", err)
+		}
+	}
+	nextErr = connect.NewError(connect.CodeUnauthenticated, errors.New("invalid token"))
+	if code := connect.CodeOf(call()); code != connect.CodeUnauthenticated {
+		t.Fatalf("first failed authentication code = %v, want %v", code, connect.CodeUnauthenticated)
+	}
+	if code := connect.CodeOf(call()); code != connect.CodeResourceExhausted {
+		t.Errorf("second failed authentication code = %v, want %v", code, connect.CodeResourceExhausted)
+	}
+	nextErr = nil
+	if code := connect.CodeOf(call()); code != connect.CodeResourceExhausted {
+		t.Errorf("call from the limited address code = %v, want %v", code, connect.CodeResourceExhausted)
+	}
+}
@@ -0,0 +1,173 @@
+// Package apierror builds Connect errors with typed error details.
+//
+// Every error carries an ErrorInfo with a stable reason so that clients can branch on it without parsing messages.
+// Depending on the reason, BadRequest, ResourceInfo, PreconditionFailure, QuotaFailure or RetryInfo details are attached as well.
+package apierror
+
+import (
+	"errors"
+	"maps"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the ErrorInfo domain of the errors returned by the API.
+const Domain = "kaas.nokamoto.github.com"
+
+// Reason is a stable, machine-readable identifier of the cause of an error.
+type Reason string
+
+const (
+	ReasonInvalidArgument        Reason = "INVALID_ARGUMENT"
+	ReasonResourceNotFound       Reason = "RESOURCE_NOT_FOUND"
+	ReasonResourceAlreadyExists  Reason = "RESOURCE_ALREADY_EXISTS"
+	ReasonConcurrentModification Reason = "CONCURRENT_MODIFICATION"
+	ReasonFailedPrecondition     Reason = "FAILED_PRECONDITION"
+	ReasonQuotaExceeded          Reason = "QUOTA_EXCEEDED"
+	ReasonRateLimited            Reason = "RATE_LIMITED"
+	ReasonBackendUnavailable     Reason = "BACKEND_UNAVAILABLE"
+	ReasonInternal               Reason = "INTERNAL"
+)
+
+// Resource types reported in ResourceInfo.
+const (
+	ResourceTypeCluster   = "api.proto.v1alpha1.Cluster"
+	ResourceTypeOperation = "api.proto.v1alpha1.LongRunningOperation"
+	ResourceTypeProject   = "project"
+)
+
+// FieldViolation describes why a request field is invalid.
+type FieldViolation struct {
+	// Field is the path of the field in the request, such as "cluster.name".
+	Field       string
+	Description string
+}
+
+// InvalidArgument returns an InvalidArgument error with a BadRequest field violation of the request field.
+func InvalidArgument(field string, err error) *connect.Error {
+	return InvalidArguments(err, FieldViolation{Field: field, Description: err.Error()})
+}
+
+// InvalidArguments returns an InvalidArgument error with a BadRequest detail listing all the field violations.
+func InvalidArguments(err error, violations ...FieldViolation) *connect.Error {
+	req := &errdetails.BadRequest{}
+	var fields []string
+	for _, v := range violations {
+		req.FieldViolations = append(req.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+		fields = append(fields, v.Field)
+	}
+	return newError(connect.CodeInvalidArgument, ReasonInvalidArgument, err, map[string]string{"field": strings.Join(fields, ",")}, req)
+}
+
+// NotFound returns a NotFound error with the ResourceInfo of the missing resource.
+func NotFound(resourceType, name string, err error) *connect.Error {
+	return newError(connect.CodeNotFound, ReasonResourceNotFound, err, map[string]string{"resource": name},
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: err.Error()},
+	)
+}
+
+// AlreadyExists returns an AlreadyExists error with the ResourceInfo of the existing resource.
+func AlreadyExists(resourceType, name string, err error) *connect.Error {
+	return newError(connect.CodeAlreadyExists, ReasonResourceAlreadyExists, err, map[string]string{"resource": name},
+		&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: err.Error()},
+	)
+}
+
+// Internal returns an Internal error for failures that are bugs of the server rather than of the request or backend.
+func Internal(err error) *connect.Error {
+	return newError(connect.CodeInternal, ReasonInternal, err, nil)
+}
+
+// RateLimited returns a ResourceExhausted error with a RetryInfo telling the caller when to retry.
+func RateLimited(err error, retryDelay time.Duration) *connect.Error {
+	return newError(connect.CodeResourceExhausted, ReasonRateLimited, err, nil,
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay)},
+	)
+}
+
+// From returns a Connect error for an error of the domain or infrastructure layer.
+// The resource type and name identify the resource the failed call was about.
+// Errors that are not part of the domain taxonomy are reported as Unavailable since they come from the backend.
+func From(resourceType, name string, err error) *connect.Error {
+	var precondition *domain.PreconditionError
+	var quota *domain.QuotaError
+	switch {
+	case errors.Is(err, domain.ErrResourceNotFound):
+		return NotFound(resourceType, name, err)
+
+	case errors.Is(err, domain.ErrAlreadyExists):
+		return AlreadyExists(resourceType, name, err)
+
+	case errors.Is(err, domain.ErrConflict):
+		return newError(connect.CodeAborted, ReasonConcurrentModification, err, map[string]string{"resource": name},
+			&errdetails.ResourceInfo{ResourceType: resourceType, ResourceName: name, Description: err.Error()},
+		)
+
+	case errors.As(err, &precondition):
+		return newError(connect.CodeFailedPrecondition, ReasonFailedPrecondition, err, map[string]string{"resource": name},
+			&errdetails.PreconditionFailure{
+				Violations: []*errdetails.PreconditionFailure_Violation{
+					{Type: precondition.Type, Subject: precondition.Subject, Description: precondition.Description},
+				},
+			},
+		)
+
+	case errors.As(err, &quota):
+		return newError(connect.CodeResourceExhausted, ReasonQuotaExceeded, err, map[string]string{"resource": name},
+			&errdetails.QuotaFailure{
+				Violations: []*errdetails.QuotaFailure_Violation{
+					{Subject: quota.Subject, Description: quota.Description},
+				},
+			},
+		)
+
+	default:
+		return newError(connect.CodeUnavailable, ReasonBackendUnavailable, err, map[string]string{"resource": name})
+	}
+}
+
+func newError(code connect.Code, reason Reason, err error, metadata map[string]string, details ...proto.Message) *connect.Error {
+	cerr := connect.NewError(code, err)
+	maps.DeleteFunc(metadata, func(_, v string) bool { return v == "" })
+	details = append(details, &errdetails.ErrorInfo{
+		Reason:   string(reason),
+		Domain:   Domain,
+		Metadata: metadata,
+	})
+	for _, d := range details {
+		detail, derr := connect.NewErrorDetail(d)
+		if derr != nil {
+			// Details are well-known messages, so this only fails on a broken protobuf registry.
+			continue
+		}
+		cerr.AddDetail(detail)
+	}
+	return cerr
+}
+
+// ReasonOf returns the reason of the ErrorInfo attached to the error, or an empty string if there is none.
+func ReasonOf(err error) Reason {
+	var cerr *connect.Error
+	if !errors.As(err, &cerr) {
+		return ""
+	}
+	for _, d := range cerr.Details() {
+		v, derr := d.Value()
+		if derr != nil {
+			continue
+		}
+		if info, ok := v.(*errdetails.ErrorInfo); ok && info.GetDomain() == Domain {
+			return Reason(info.GetReason())
+		}
+	}
+	return ""
+}
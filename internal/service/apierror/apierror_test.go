@@ -0,0 +1,159 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func details(t *testing.T, err *connect.Error) []proto.Message {
+	t.Helper()
+	var res []proto.Message
+	for _, d := range err.Details() {
+		v, derr := d.Value()
+		if derr != nil {
+			t.Fatalf("failed to unmarshal detail: %v", derr)
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+func TestFrom(t *testing.T) {
+	const name = "projects/p1/clusters/c1"
+	precondition := &domain.PreconditionError{
+		Type:        "PROJECT_NAMESPACE",
+		Subject:     "namespaces/project-p1",
+		Description: "not provisioned",
+	}
+	tests := []struct {
+		name   string
+		err    error
+		code   connect.Code
+		reason Reason
+		want   []proto.Message
+	}{
+		{
+			name:   "not found",
+			err:    errors.Join(domain.ErrResourceNotFound, errors.New("missing")),
+			code:   connect.CodeNotFound,
+			reason: ReasonResourceNotFound,
+			want: []proto.Message{
+				&errdetails.ResourceInfo{ResourceType: ResourceTypeCluster, ResourceName: name, Description: "resource not found\nmissing"},
+			},
+		},
+		{
+			name:   "already exists",
+			err:    domain.ErrAlreadyExists,
+			code:   connect.CodeAlreadyExists,
+			reason: ReasonResourceAlreadyExists,
+			want: []proto.Message{
+				&errdetails.ResourceInfo{ResourceType: ResourceTypeCluster, ResourceName: name, Description: "resource already exists"},
+			},
+		},
+		{
+			name:   "conflict",
+			err:    fmt.Errorf("update: %w", domain.ErrConflict),
+			code:   connect.CodeAborted,
+			reason: ReasonConcurrentModification,
+			want: []proto.Message{
+				&errdetails.ResourceInfo{ResourceType: ResourceTypeCluster, ResourceName: name, Description: "update: resource modified concurrently"},
+			},
+		},
+		{
+			name:   "precondition",
+			err:    fmt.Errorf("ensure: %w", precondition),
+			code:   connect.CodeFailedPrecondition,
+			reason: ReasonFailedPrecondition,
+			want: []proto.Message{
+				&errdetails.PreconditionFailure{
+					Violations: []*errdetails.PreconditionFailure_Violation{
+						{Type: "PROJECT_NAMESPACE", Subject: "namespaces/project-p1", Description: "not provisioned"},
+					},
+				},
+			},
+		},
+		{
+			name:   "quota",
+			err:    &domain.QuotaError{Subject: "projects/p1", Description: "too many clusters"},
+			code:   connect.CodeResourceExhausted,
+			reason: ReasonQuotaExceeded,
+			want: []proto.Message{
+				&errdetails.QuotaFailure{
+					Violations: []*errdetails.QuotaFailure_Violation{
+						{Subject: "projects/p1", Description: "too many clusters"},
+					},
+				},
+			},
+		},
+		{
+			name:   "unknown",
+			err:    errors.New("connection refused"),
+			code:   connect.CodeUnavailable,
+			reason: ReasonBackendUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := From(ResourceTypeCluster, name, tt.err)
+			if got.Code() != tt.code {
+				t.Errorf("code = %v, want %v", got.Code(), tt.code)
+			}
+			if !errors.Is(got, tt.err) {
+				t.Errorf("error does not wrap %v", tt.err)
+			}
+			want := append(tt.want, &errdetails.ErrorInfo{
+				Reason:   string(tt.reason),
+				Domain:   Domain,
+				Metadata: map[string]string{"resource": name},
+			})
+			if diff := cmp.Diff(want, details(t, got), protocmp.Transform()); diff != "" {
+				t.Errorf("details mismatch (-want +got):\n%s", diff)
+			}
+			if reason := ReasonOf(got); reason != tt.reason {
+				t.Errorf("ReasonOf() = %v, want %v", reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestInvalidArgument(t *testing.T) {
+	got := InvalidArgument("parent", errors.New("invalid"))
+	if got.Code() != connect.CodeInvalidArgument {
+		t.Errorf("code = %v, want %v", got.Code(), connect.CodeInvalidArgument)
+	}
+	want := []proto.Message{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "parent", Description: "invalid"},
+			},
+		},
+		&errdetails.ErrorInfo{
+			Reason:   string(ReasonInvalidArgument),
+			Domain:   Domain,
+			Metadata: map[string]string{"field": "parent"},
+		},
+	}
+	if diff := cmp.Diff(want, details(t, got), protocmp.Transform()); diff != "" {
+		t.Errorf("details mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReasonOf(t *testing.T) {
+	if got := ReasonOf(errors.New("plain")); got != "" {
+		t.Errorf("ReasonOf() = %v, want empty", got)
+	}
+	if got := ReasonOf(connect.NewError(connect.CodeInternal, errors.New("no details"))); got != "" {
+		t.Errorf("ReasonOf() = %v, want empty", got)
+	}
+	if got := ReasonOf(fmt.Errorf("wrapped: %w", Internal(errors.New("bug")))); got != ReasonInternal {
+		t.Errorf("ReasonOf() = %v, want %v", got, ReasonInternal)
+	}
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nokamoto/kaas-operator-prototype/internal/service/audit (interfaces: store)
+//
+// Generated by this command:
+//
+//	mockgen -package audit -destination mock_audit_test.go . store
+//
+
+// Package audit is a generated GoMock package.
+package audit
+
+import (
+	reflect "reflect"
+
+	audit "github.com/nokamoto/kaas-operator-prototype/internal/audit"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockstore is a mock of store interface.
+type Mockstore struct {
+	ctrl     *gomock.Controller
+	recorder *MockstoreMockRecorder
+	isgomock struct{}
+}
+
+// MockstoreMockRecorder is the mock recorder for Mockstore.
+type MockstoreMockRecorder struct {
+	mock *Mockstore
+}
+
+// NewMockstore creates a new mock instance.
+func NewMockstore(ctrl *gomock.Controller) *Mockstore {
+	mock := &Mockstore{ctrl: ctrl}
+	mock.recorder = &MockstoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockstore) EXPECT() *MockstoreMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *Mockstore) List(project string, limit int) []audit.Event {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", project, limit)
+	ret0, _ := ret[0].([]audit.Event)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockstoreMockRecorder) List(project, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*Mockstore)(nil).List), project, limit)
+}
@@ -0,0 +1,78 @@
+//go:generate mockgen -package audit -destination mock_audit_test.go . store
+package audit
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	auditlog "github.com/nokamoto/kaas-operator-prototype/internal/audit"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultPageSize is the number of events returned if the page size is not specified.
+const defaultPageSize = 100
+
+type store interface {
+	List(project string, limit int) []auditlog.Event
+}
+
+type AuditService struct {
+	v1alpha1connect.UnimplementedAuditServiceHandler
+	store store
+}
+
+func New(store store) *AuditService {
+	return &AuditService{
+		store: store,
+	}
+}
+
+// ListAuditEvents lists the recent audit events of the project, newest first.
+func (a *AuditService) ListAuditEvents(
+	_ context.Context,
+	req *connect.Request[apiv1alpha1.ListAuditEventsRequest],
+) (*connect.Response[apiv1alpha1.ListAuditEventsResponse], error) {
+	project, err := domain.ParseProjectName(req.Msg.GetParent())
+	if err != nil {
+		return nil, apierror.InvalidArgument("parent", err)
+	}
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	res := &apiv1alpha1.ListAuditEventsResponse{}
+	for _, e := range a.store.List(project, pageSize) {
+		event, err := newAuditEvent(e)
+		if err != nil {
+			return nil, apierror.Internal(err)
+		}
+		res.Events = append(res.Events, event)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func newAuditEvent(e auditlog.Event) (*apiv1alpha1.AuditEvent, error) {
+	event := &apiv1alpha1.AuditEvent{
+		Time:      timestamppb.New(e.Time),
+		Subject:   e.Subject,
+		Procedure: e.Procedure,
+		Project:   e.Project,
+		Operation: e.Operation,
+		Pipeline:  e.Pipeline,
+		Code:      e.Code,
+		Message:   e.Message,
+	}
+	if e.Request != nil {
+		req, err := structpb.NewStruct(e.Request)
+		if err != nil {
+			return nil, err
+		}
+		event.Request = req
+	}
+	return event, nil
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	auditlog "github.com/nokamoto/kaas-operator-prototype/internal/audit"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAuditService_ListAuditEvents(t *testing.T) {
+	now := time.Now()
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.ListAuditEventsRequest
+		mock func(*Mockstore)
+		want *apiv1alpha1.ListAuditEventsResponse
+		code connect.Code
+	}
+	tests := []testcase{
+		{
+			name: "ok with the default page size",
+			req:  &apiv1alpha1.ListAuditEventsRequest{Parent: "projects/p1"},
+			mock: func(store *Mockstore) {
+				store.EXPECT().List("p1", defaultPageSize).Return([]auditlog.Event{
+					{
+						Time:      now,
+						Subject:   "alice",
+						Procedure: "/api.proto.v1alpha1.ClusterService/CreateCluster",
+						Project:   "p1",
+						Request:   map[string]any{"parent": "projects/p1"},
+						Operation: "projects/p1/operations/cluster-create-1",
+						Pipeline:  "cluster-create-1",
+						Code:      "ok",
+					},
+				})
+			},
+			want: &apiv1alpha1.ListAuditEventsResponse{
+				Events: []*apiv1alpha1.AuditEvent{
+					{
+						Time:      timestamppb.New(now),
+						Subject:   "alice",
+						Procedure: "/api.proto.v1alpha1.ClusterService/CreateCluster",
+						Project:   "p1",
+						Request: &structpb.Struct{Fields: map[string]*structpb.Value{
+							"parent": structpb.NewStringValue("projects/p1"),
+						}},
+						Operation: "projects/p1/operations/cluster-create-1",
+						Pipeline:  "cluster-create-1",
+						Code:      "ok",
+					},
+				},
+			},
+		},
+		{
+			name: "ok with a page size",
+			req:  &apiv1alpha1.ListAuditEventsRequest{Parent: "projects/p1", PageSize: 5},
+			mock: func(store *Mockstore) {
+				store.EXPECT().List("p1", 5).Return(nil)
+			},
+			want: &apiv1alpha1.ListAuditEventsResponse{},
+		},
+		{
+			name: "invalid argument if parent is not a project",
+			req:  &apiv1alpha1.ListAuditEventsRequest{Parent: "p1"},
+			code: connect.CodeInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			store := NewMockstore(ctrl)
+			if tt.mock != nil {
+				tt.mock(store)
+			}
+			service := New(store)
+			res, err := service.ListAuditEvents(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("ListAuditEvents() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("ListAuditEvents() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
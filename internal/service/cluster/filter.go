@@ -0,0 +1,66 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+)
+
+// filterTerm matches a comparison of the filter expression at the beginning of the rest of the expression,
+// followed by AND and the next comparison or by the end of the expression.
+var filterTerm = regexp.MustCompile(`^\s*([a-z_]+)\s*(!=|=)\s*("(?:[^"\\]|\\.)*")\s*(?:AND\s+|$)`)
+
+// filterFields are the fields of Cluster that the filter expression compares.
+var filterFields = map[string]func(*apiv1alpha1.Cluster) string{
+	"name":         (*apiv1alpha1.Cluster).GetName,
+	"display_name": (*apiv1alpha1.Cluster).GetDisplayName,
+	"description":  (*apiv1alpha1.Cluster).GetDescription,
+}
+
+type comparison struct {
+	field func(*apiv1alpha1.Cluster) string
+	equal bool
+	value string
+}
+
+// clusterFilter is a parsed filter expression that matches the clusters satisfying all its comparisons.
+type clusterFilter []comparison
+
+// parseFilter parses a filter expression of comparisons such as `display_name = "dev"` joined by AND.
+// A comparison is a field of name, display_name or description, = or !=, and a double-quoted string.
+// An empty expression matches every cluster.
+func parseFilter(expr string) (clusterFilter, error) {
+	var f clusterFilter
+	rest := strings.TrimSpace(expr)
+	for rest != "" {
+		m := filterTerm.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("invalid filter %q, must be comparisons such as `display_name = \"dev\"` joined by AND", expr)
+		}
+		field, ok := filterFields[m[1]]
+		if !ok {
+			return nil, fmt.Errorf("invalid filter field %q, must be one of description, display_name or name", m[1])
+		}
+		value, err := strconv.Unquote(m[3])
+		if err != nil {
+			return nil, errors.Join(fmt.Errorf("invalid filter value %s", m[3]), err)
+		}
+		f = append(f, comparison{field: field, equal: m[2] == "=", value: value})
+		rest = rest[len(m[0]):]
+	}
+	return f, nil
+}
+
+// match returns true if the cluster satisfies all the comparisons.
+func (f clusterFilter) match(c *apiv1alpha1.Cluster) bool {
+	for _, cmp := range f {
+		if (cmp.field(c) == cmp.value) != cmp.equal {
+			return false
+		}
+	}
+	return true
+}
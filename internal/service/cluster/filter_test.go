@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"testing"
+
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+)
+
+func TestParseFilter(t *testing.T) {
+	cluster := &apiv1alpha1.Cluster{
+		Name:        "projects/test-project/clusters/c1",
+		DisplayName: "dev",
+		Description: `the "AND" cluster`,
+	}
+	tests := []struct {
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{expr: "", want: true},
+		{expr: `display_name = "dev"`, want: true},
+		{expr: `display_name="prod"`, want: false},
+		{expr: `display_name != "prod"`, want: true},
+		{expr: `name = "projects/test-project/clusters/c1" AND display_name = "dev"`, want: true},
+		{expr: `display_name = "dev" AND description = "other"`, want: false},
+		{expr: `description = "the \"AND\" cluster"`, want: true},
+		{expr: `display_name = dev`, wantErr: true},
+		{expr: `display_name = "dev" AND`, wantErr: true},
+		{expr: `display_name = "dev" OR display_name = "prod"`, wantErr: true},
+		{expr: `phase = "Running"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := parseFilter(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := f.match(cluster); got != tt.want {
+				t.Errorf("match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/nokamoto/kaas-operator-prototype/internal/service/cluster (interfaces: client,namegen)
+// Source: github.com/nokamoto/kaas-operator-prototype/internal/service/cluster (interfaces: client,namegen,quota)
 //
 // Generated by this command:
 //
-//	mockgen -package cluster -destination mock_cluster_test.go . client,namegen
+//	mockgen -package cluster -destination mock_cluster_test.go . client,namegen,quota
 //
 
 // Package cluster is a generated GoMock package.
@@ -42,17 +42,90 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 }
 
 // CreatePipeline mocks base method.
-func (m *Mockclient) CreatePipeline(ctx context.Context, pipeline *v1alpha1.Pipeline) error {
+func (m *Mockclient) CreatePipeline(ctx context.Context, project string, pipeline *v1alpha1.Pipeline) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePipeline", ctx, pipeline)
+	ret := m.ctrl.Call(m, "CreatePipeline", ctx, project, pipeline)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreatePipeline indicates an expected call of CreatePipeline.
-func (mr *MockclientMockRecorder) CreatePipeline(ctx, pipeline any) *gomock.Call {
+func (mr *MockclientMockRecorder) CreatePipeline(ctx, project, pipeline any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipeline", reflect.TypeOf((*Mockclient)(nil).CreatePipeline), ctx, pipeline)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipeline", reflect.TypeOf((*Mockclient)(nil).CreatePipeline), ctx, project, pipeline)
+}
+
+// EnsureProject mocks base method.
+func (m *Mockclient) EnsureProject(ctx context.Context, project string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureProject", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureProject indicates an expected call of EnsureProject.
+func (mr *MockclientMockRecorder) EnsureProject(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureProject", reflect.TypeOf((*Mockclient)(nil).EnsureProject), ctx, project)
+}
+
+// GetKubernetesCluster mocks base method.
+func (m *Mockclient) GetKubernetesCluster(ctx context.Context, project, name string) (*v1alpha1.KubernetesCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKubernetesCluster", ctx, project, name)
+	ret0, _ := ret[0].(*v1alpha1.KubernetesCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKubernetesCluster indicates an expected call of GetKubernetesCluster.
+func (mr *MockclientMockRecorder) GetKubernetesCluster(ctx, project, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesCluster", reflect.TypeOf((*Mockclient)(nil).GetKubernetesCluster), ctx, project, name)
+}
+
+// ListKubernetesClusters mocks base method.
+func (m *Mockclient) ListKubernetesClusters(ctx context.Context, project string) ([]v1alpha1.KubernetesCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKubernetesClusters", ctx, project)
+	ret0, _ := ret[0].([]v1alpha1.KubernetesCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKubernetesClusters indicates an expected call of ListKubernetesClusters.
+func (mr *MockclientMockRecorder) ListKubernetesClusters(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKubernetesClusters", reflect.TypeOf((*Mockclient)(nil).ListKubernetesClusters), ctx, project)
+}
+
+// ListPipelines mocks base method.
+func (m *Mockclient) ListPipelines(ctx context.Context, project string, labels map[string]string) ([]v1alpha1.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPipelines", ctx, project, labels)
+	ret0, _ := ret[0].([]v1alpha1.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPipelines indicates an expected call of ListPipelines.
+func (mr *MockclientMockRecorder) ListPipelines(ctx, project, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelines", reflect.TypeOf((*Mockclient)(nil).ListPipelines), ctx, project, labels)
+}
+
+// UpdateKubernetesCluster mocks base method.
+func (m *Mockclient) UpdateKubernetesCluster(ctx context.Context, project string, kc *v1alpha1.KubernetesCluster) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateKubernetesCluster", ctx, project, kc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateKubernetesCluster indicates an expected call of UpdateKubernetesCluster.
+func (mr *MockclientMockRecorder) UpdateKubernetesCluster(ctx, project, kc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateKubernetesCluster", reflect.TypeOf((*Mockclient)(nil).UpdateKubernetesCluster), ctx, project, kc)
 }
 
 // Mocknamegen is a mock of namegen interface.
@@ -97,3 +170,55 @@ func (mr *MocknamegenMockRecorder) New(format any, v ...any) *gomock.Call {
 	varargs := append([]any{format}, v...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*Mocknamegen)(nil).New), varargs...)
 }
+
+// NewID mocks base method.
+func (m *Mocknamegen) NewID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// NewID indicates an expected call of NewID.
+func (mr *MocknamegenMockRecorder) NewID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewID", reflect.TypeOf((*Mocknamegen)(nil).NewID))
+}
+
+// Mockquota is a mock of quota interface.
+type Mockquota struct {
+	ctrl     *gomock.Controller
+	recorder *MockquotaMockRecorder
+	isgomock struct{}
+}
+
+// MockquotaMockRecorder is the mock recorder for Mockquota.
+type MockquotaMockRecorder struct {
+	mock *Mockquota
+}
+
+// NewMockquota creates a new mock instance.
+func NewMockquota(ctrl *gomock.Controller) *Mockquota {
+	mock := &Mockquota{ctrl: ctrl}
+	mock.recorder = &MockquotaMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockquota) EXPECT() *MockquotaMockRecorder {
+	return m.recorder
+}
+
+// CheckCreateCluster mocks base method.
+func (m *Mockquota) CheckCreateCluster(ctx context.Context, project string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCreateCluster", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCreateCluster indicates an expected call of CheckCreateCluster.
+func (mr *MockquotaMockRecorder) CheckCreateCluster(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCreateCluster", reflect.TypeOf((*Mockquota)(nil).CheckCreateCluster), ctx, project)
+}
@@ -1,63 +1,442 @@
-//go:generate mockgen -package cluster -destination mock_cluster_test.go . client,namegen
+//go:generate mockgen -package cluster -destination mock_cluster_test.go . client,namegen,quota
 package cluster
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"connectrpc.com/connect"
 	typev1alpha1 "github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	"github.com/nokamoto/kaas-operator-prototype/internal/tracing"
 	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-const defaultNamespace = "default"
-
 type client interface {
-	CreatePipeline(ctx context.Context, pipeline *typev1alpha1.Pipeline) error
+	EnsureProject(ctx context.Context, project string) error
+	CreatePipeline(ctx context.Context, project string, pipeline *typev1alpha1.Pipeline) error
+	ListPipelines(ctx context.Context, project string, labels map[string]string) ([]typev1alpha1.Pipeline, error)
+	GetKubernetesCluster(ctx context.Context, project, name string) (*typev1alpha1.KubernetesCluster, error)
+	ListKubernetesClusters(ctx context.Context, project string) ([]typev1alpha1.KubernetesCluster, error)
+	UpdateKubernetesCluster(ctx context.Context, project string, kc *typev1alpha1.KubernetesCluster) error
 }
 
 type namegen interface {
 	New(format string, v ...any) string
+	NewID() string
+}
+
+type quota interface {
+	CheckCreateCluster(ctx context.Context, project string) error
 }
 
+// updatableFields are the fields of Cluster that UpdateCluster accepts in the update mask.
+// They are metadata applied directly to the KubernetesCluster annotations.
+var updatableFields = []string{"description", "display_name"}
+
+// defaultPageSize is the number of clusters ListClusters returns per page if the request does not specify it,
+// and maxPageSize is the maximum number the request can specify.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// maxClusterIDAttempts is the maximum number of generated cluster IDs tried before giving up on collisions.
+const maxClusterIDAttempts = 5
+
 type ClusterService struct {
 	v1alpha1connect.UnimplementedClusterServiceHandler
 	client  client
 	namegen namegen
+	quota   quota
 }
 
-func New(client client, namegen namegen) *ClusterService {
+func New(client client, namegen namegen, quota quota) *ClusterService {
 	return &ClusterService{
 		client:  client,
 		namegen: namegen,
+		quota:   quota,
 	}
 }
 
 // CreateCluster creates a pipeline resource to start a cluster creation operation.
+// The pipeline is created in the namespace of the parent project, which is provisioned on the first creation.
+// The cluster ID is taken from the request if given, or generated otherwise; it must not be in use in the project.
+// The request fails with RESOURCE_EXHAUSTED if the project has reached its cluster or pending operation quota.
 // It returns a LongRunningOperation that can be used to track the progress of the operation.
+// If the request has a request ID that already created a pipeline, the operation of that pipeline is returned instead.
+// The pipeline of a request ID has a name derived from it, so that only one of concurrent retries creates it.
+// The trace context of the request is propagated to the pipeline so that the controllers continue the trace.
 func (c *ClusterService) CreateCluster(
 	ctx context.Context,
 	req *connect.Request[apiv1alpha1.CreateClusterRequest],
 ) (*connect.Response[apiv1alpha1.LongRunningOperation], error) {
+	project, err := domain.ParseProjectName(req.Msg.GetParent())
+	if err != nil {
+		return nil, apierror.InvalidArgument("parent", err)
+	}
+	requestID := req.Msg.GetRequestId()
+	if requestID != "" {
+		if errs := validation.IsValidLabelValue(requestID); len(errs) > 0 {
+			return nil, apierror.InvalidArgument("request_id", fmt.Errorf("invalid request_id: %s", strings.Join(errs, ", ")))
+		}
+		op, err := c.requestOperation(ctx, project, requestID)
+		if err != nil {
+			return nil, apierror.From(apierror.ResourceTypeOperation, "", err)
+		}
+		if op != nil {
+			return connect.NewResponse(op), nil
+		}
+	}
+	clusterID := req.Msg.GetClusterId()
+	if clusterID != "" {
+		if err := domain.ValidateClusterID(clusterID); err != nil {
+			return nil, apierror.InvalidArgument("cluster_id", err)
+		}
+		name := domain.ClusterName{Project: project, Cluster: clusterID}.String()
+		exists, err := c.clusterExists(ctx, project, clusterID)
+		if err != nil {
+			return nil, apierror.From(apierror.ResourceTypeCluster, name, err)
+		}
+		if exists && requestID != "" {
+			// A concurrent retry of the request may have started creating the cluster since the check above
+			op, err := c.requestOperation(ctx, project, requestID)
+			if err != nil {
+				return nil, apierror.From(apierror.ResourceTypeOperation, "", err)
+			}
+			if op != nil {
+				return connect.NewResponse(op), nil
+			}
+		}
+		if exists {
+			return nil, apierror.AlreadyExists(apierror.ResourceTypeCluster, name, fmt.Errorf("cluster %s already exists", name))
+		}
+	} else {
+		clusterID, err = c.newClusterID(ctx, project)
+		if err != nil {
+			return nil, apierror.From(apierror.ResourceTypeCluster, "", err)
+		}
+	}
+	if err := c.quota.CheckCreateCluster(ctx, project); err != nil {
+		return nil, apierror.From(apierror.ResourceTypeProject, req.Msg.GetParent(), err)
+	}
+	if err := c.client.EnsureProject(ctx, project); err != nil {
+		return nil, apierror.From(apierror.ResourceTypeProject, req.Msg.GetParent(), err)
+	}
 	cluster := req.Msg.GetCluster()
+	pipelineName := requestPipelineName(requestID)
+	if requestID == "" {
+		pipelineName = c.namegen.New("cluster-create")
+	}
 	pipeline := &typev1alpha1.Pipeline{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      c.namegen.New("cluster-create"),
-			Namespace: defaultNamespace,
+			Name: pipelineName,
+			Labels: map[string]string{
+				typev1alpha1.PipelineLabelCluster: clusterID,
+			},
 		},
 		Spec: typev1alpha1.PipelineSpec{
 			Cluster: typev1alpha1.PipelineClusterSpec{
-				Name:        c.namegen.New("kubernetescluster"),
+				Name:        clusterID,
 				DisplayName: cluster.GetDisplayName(),
 				Description: cluster.GetDescription(),
 			},
 		},
 	}
-	if err := c.client.CreatePipeline(ctx, pipeline); err != nil {
-		return nil, connect.NewError(connect.CodeUnavailable, err)
+	if requestID != "" {
+		pipeline.Labels[typev1alpha1.PipelineLabelRequestID] = requestID
+	}
+	tracing.Inject(ctx, pipeline)
+	op := &apiv1alpha1.LongRunningOperation{
+		Name: domain.OperationName{Project: project, Operation: pipeline.Name}.String(),
+	}
+	err = c.client.CreatePipeline(ctx, project, pipeline)
+	if requestID != "" && errors.Is(err, domain.ErrAlreadyExists) {
+		// A concurrent retry of the request has created the pipeline first
+		return connect.NewResponse(op), nil
+	}
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeOperation, op.GetName(), err)
+	}
+	return connect.NewResponse(op), nil
+}
+
+// requestOperation returns the operation of the pipeline created by the request ID, or nil if there is none.
+func (c *ClusterService) requestOperation(ctx context.Context, project, requestID string) (*apiv1alpha1.LongRunningOperation, error) {
+	pipelines, err := c.client.ListPipelines(ctx, project, map[string]string{
+		typev1alpha1.PipelineLabelRequestID: requestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+	return &apiv1alpha1.LongRunningOperation{
+		Name: domain.OperationName{Project: project, Operation: pipelines[0].Name}.String(),
+	}, nil
+}
+
+// requestPipelineName derives the name of the pipeline from the request ID.
+// Retries of a request race to create the same pipeline, and all but the first fail with ErrAlreadyExists.
+func requestPipelineName(requestID string) string {
+	sum := sha256.Sum256([]byte(requestID))
+	return "cluster-create-" + hex.EncodeToString(sum[:16])
+}
+
+// clusterExists returns true if the KubernetesCluster exists or a pipeline in progress is creating it.
+// A pipeline that has succeeded has created the KubernetesCluster, so its ID is reusable once the cluster is deleted.
+func (c *ClusterService) clusterExists(ctx context.Context, project, clusterID string) (bool, error) {
+	_, err := c.client.GetKubernetesCluster(ctx, project, clusterID)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, domain.ErrResourceNotFound) {
+		return false, err
+	}
+	pipelines, err := c.client.ListPipelines(ctx, project, map[string]string{
+		typev1alpha1.PipelineLabelCluster: clusterID,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, p := range pipelines {
+		if !p.IsDelete() && inProgress(&p) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newClusterID generates a human-readable cluster ID that is not in use in the project.
+func (c *ClusterService) newClusterID(ctx context.Context, project string) (string, error) {
+	for range maxClusterIDAttempts {
+		id := c.namegen.NewID()
+		exists, err := c.clusterExists(ctx, project, id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique cluster ID in %d attempts", maxClusterIDAttempts)
+}
+
+// UpdateCluster updates the fields of the cluster selected by the update mask.
+// Metadata fields are applied directly to the KubernetesCluster, so the returned operation is already done.
+func (c *ClusterService) UpdateCluster(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.UpdateClusterRequest],
+) (*connect.Response[apiv1alpha1.LongRunningOperation], error) {
+	cluster := req.Msg.GetCluster()
+	name, err := domain.ParseClusterName(cluster.GetName())
+	if err != nil {
+		return nil, apierror.InvalidArgument("cluster.name", err)
+	}
+	paths, err := updatePaths(req.Msg.GetUpdateMask())
+	if err != nil {
+		return nil, apierror.InvalidArgument("update_mask", err)
+	}
+	kc, err := c.client.GetKubernetesCluster(ctx, name.Project, name.Cluster)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeCluster, name.String(), err)
+	}
+	if kc.Annotations == nil {
+		kc.Annotations = map[string]string{}
+	}
+	for _, path := range paths {
+		switch path {
+		case "display_name":
+			kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDisplayName] = cluster.GetDisplayName()
+		case "description":
+			kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDescription] = cluster.GetDescription()
+		}
+	}
+	if err := c.client.UpdateKubernetesCluster(ctx, name.Project, kc); err != nil {
+		return nil, apierror.From(apierror.ResourceTypeCluster, name.String(), err)
+	}
+	res, err := anypb.New(newCluster(name.Project, kc))
+	if err != nil {
+		return nil, apierror.Internal(err)
 	}
 	return connect.NewResponse(&apiv1alpha1.LongRunningOperation{
-		Name: pipeline.Name,
+		Done:     true,
+		Response: res,
 	}), nil
 }
+
+// GetCluster returns the cluster of the KubernetesCluster in the namespace of the project.
+func (c *ClusterService) GetCluster(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.GetClusterRequest],
+) (*connect.Response[apiv1alpha1.Cluster], error) {
+	name, err := domain.ParseClusterName(req.Msg.GetName())
+	if err != nil {
+		return nil, apierror.InvalidArgument("name", err)
+	}
+	kc, err := c.client.GetKubernetesCluster(ctx, name.Project, name.Cluster)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeCluster, name.String(), err)
+	}
+	return connect.NewResponse(newCluster(name.Project, kc)), nil
+}
+
+// ListClusters returns the clusters of the KubernetesClusters in the namespace of the project that match the filter.
+// The clusters are ordered by name, and the page token is the opaque position after the last cluster of the previous page,
+// so that a cluster created or deleted between the pages does not shift the following ones.
+func (c *ClusterService) ListClusters(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.ListClustersRequest],
+) (*connect.Response[apiv1alpha1.ListClustersResponse], error) {
+	project, err := domain.ParseProjectName(req.Msg.GetParent())
+	if err != nil {
+		return nil, apierror.InvalidArgument("parent", err)
+	}
+	filter, err := parseFilter(req.Msg.GetFilter())
+	if err != nil {
+		return nil, apierror.InvalidArgument("filter", err)
+	}
+	after, err := parsePageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, apierror.InvalidArgument("page_token", err)
+	}
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+	kcs, err := c.client.ListKubernetesClusters(ctx, project)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeProject, req.Msg.GetParent(), err)
+	}
+	slices.SortFunc(kcs, func(a, b typev1alpha1.KubernetesCluster) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	res := &apiv1alpha1.ListClustersResponse{}
+	for _, kc := range kcs {
+		if kc.Name <= after {
+			continue
+		}
+		cluster := newCluster(project, &kc)
+		if !filter.match(cluster) {
+			continue
+		}
+		if len(res.Clusters) == pageSize {
+			res.NextPageToken = newPageToken(res.Clusters[pageSize-1])
+			break
+		}
+		res.Clusters = append(res.Clusters, cluster)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// DeleteCluster creates a pipeline resource to start a cluster deletion operation.
+// If a pipeline is already deleting the cluster, the operation of that pipeline is returned instead.
+// It returns a LongRunningOperation that can be used to track the progress of the operation.
+func (c *ClusterService) DeleteCluster(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.DeleteClusterRequest],
+) (*connect.Response[apiv1alpha1.LongRunningOperation], error) {
+	name, err := domain.ParseClusterName(req.Msg.GetName())
+	if err != nil {
+		return nil, apierror.InvalidArgument("name", err)
+	}
+	if _, err := c.client.GetKubernetesCluster(ctx, name.Project, name.Cluster); err != nil {
+		return nil, apierror.From(apierror.ResourceTypeCluster, name.String(), err)
+	}
+	pipelines, err := c.client.ListPipelines(ctx, name.Project, map[string]string{
+		typev1alpha1.PipelineLabelCluster: name.Cluster,
+	})
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeCluster, name.String(), err)
+	}
+	for _, p := range pipelines {
+		if p.IsDelete() && inProgress(&p) {
+			return connect.NewResponse(&apiv1alpha1.LongRunningOperation{
+				Name: domain.OperationName{Project: name.Project, Operation: p.Name}.String(),
+			}), nil
+		}
+	}
+	pipeline := &typev1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: c.namegen.New("cluster-delete"),
+			Labels: map[string]string{
+				typev1alpha1.PipelineLabelCluster: name.Cluster,
+			},
+		},
+		Spec: typev1alpha1.PipelineSpec{
+			Cluster:   typev1alpha1.PipelineClusterSpec{Name: name.Cluster},
+			Operation: typev1alpha1.PipelineOperationDelete,
+		},
+	}
+	tracing.Inject(ctx, pipeline)
+	op := &apiv1alpha1.LongRunningOperation{
+		Name: domain.OperationName{Project: name.Project, Operation: pipeline.Name}.String(),
+	}
+	if err := c.client.CreatePipeline(ctx, name.Project, pipeline); err != nil {
+		return nil, apierror.From(apierror.ResourceTypeOperation, op.GetName(), err)
+	}
+	return connect.NewResponse(op), nil
+}
+
+// inProgress returns true if the pipeline has not succeeded or failed yet.
+func inProgress(p *typev1alpha1.Pipeline) bool {
+	return p.Status.Phase != typev1alpha1.PipelinePhaseSucceeded && p.Status.Phase != typev1alpha1.PipelinePhaseFailed
+}
+
+func newCluster(project string, kc *typev1alpha1.KubernetesCluster) *apiv1alpha1.Cluster {
+	return &apiv1alpha1.Cluster{
+		Name:        domain.ClusterName{Project: project, Cluster: kc.Name}.String(),
+		DisplayName: kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDisplayName],
+		Description: kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDescription],
+	}
+}
+
+// newPageToken returns the page token of the page after the cluster.
+func newPageToken(last *apiv1alpha1.Cluster) string {
+	name, _ := domain.ParseClusterName(last.GetName())
+	return base64.RawURLEncoding.EncodeToString([]byte(name.Cluster))
+}
+
+// parsePageToken returns the cluster ID after which the page starts, or empty for the first page.
+func parsePageToken(token string) (string, error) {
+	after, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", errors.Join(errors.New("invalid page_token"), err)
+	}
+	if len(after) > 0 {
+		if err := domain.ValidateClusterID(string(after)); err != nil {
+			return "", errors.Join(errors.New("invalid page_token"), err)
+		}
+	}
+	return string(after), nil
+}
+
+// updatePaths validates the update mask and returns its paths, expanding "*" to all updatable fields.
+func updatePaths(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, errors.New("update_mask is required")
+	}
+	if slices.Equal(mask.GetPaths(), []string{"*"}) {
+		return updatableFields, nil
+	}
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatableFields, path) {
+			return nil, fmt.Errorf("update_mask path %q is not updatable, must be one of %s or *", path, strings.Join(updatableFields, ", "))
+		}
+	}
+	return mask.GetPaths(), nil
+}
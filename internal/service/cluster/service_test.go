@@ -8,18 +8,654 @@ import (
 	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
 	typev1alpha1 "github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
 	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	gomock "go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestClusterService_CreateCluster(t *testing.T) {
 	testPipelineName := "test-cluster"
-	testClusterName := "test-kubernetescluster"
+	testClusterName := "calm-falcon-7c2e"
+	testProject := "test-project"
+	testParent := "projects/" + testProject
+	testRequestID := "8c1f7a52-6b43-4f0e-9d3a-2b8f5e6c7d10"
 	type testcase struct {
 		name string
 		req  *apiv1alpha1.CreateClusterRequest
+		mock func(*Mockclient, *Mocknamegen, *Mockquota)
+		want *apiv1alpha1.LongRunningOperation
+		code connect.Code
+	}
+	// generated expects the generated cluster ID to be checked for collisions and found unused.
+	generated := func(client *Mockclient, namegen *Mocknamegen) []any {
+		return []any{
+			namegen.EXPECT().NewID().Return(testClusterName),
+			client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(nil, domain.ErrResourceNotFound),
+			client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+				typev1alpha1.PipelineLabelCluster: testClusterName,
+			}).Return(nil, nil),
+		}
+	}
+	testRequestPipelineName := requestPipelineName(testRequestID)
+	pipeline := func(labels map[string]string) *typev1alpha1.Pipeline {
+		name := testPipelineName
+		if labels[typev1alpha1.PipelineLabelRequestID] != "" {
+			name = testRequestPipelineName
+		}
+		return &typev1alpha1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+			},
+			Spec: typev1alpha1.PipelineSpec{
+				Cluster: typev1alpha1.PipelineClusterSpec{
+					Name: testClusterName,
+				},
+			},
+		}
+	}
+	tests := []testcase{
+		{
+			name: "ok if pipeline creation succeeds",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(generated(client, namegen),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					namegen.EXPECT().New("cluster-create").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, pipeline(map[string]string{
+						typev1alpha1.PipelineLabelCluster: testClusterName,
+					})).Return(nil),
+				)...)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testPipelineName,
+			},
+		},
+		{
+			name: "ok with a new request ID labeled on the pipeline named after it",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: testRequestID},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(append([]any{
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+						typev1alpha1.PipelineLabelRequestID: testRequestID,
+					}).Return(nil, nil),
+				}, generated(client, namegen)...),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, pipeline(map[string]string{
+						typev1alpha1.PipelineLabelCluster:   testClusterName,
+						typev1alpha1.PipelineLabelRequestID: testRequestID,
+					})).Return(nil),
+				)...)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testRequestPipelineName,
+			},
+		},
+		{
+			name: "ok with the operation of a concurrent retry that created the pipeline first",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: testRequestID},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(append([]any{
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+						typev1alpha1.PipelineLabelRequestID: testRequestID,
+					}).Return(nil, nil),
+				}, generated(client, namegen)...),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, gomock.Any()).Return(domain.ErrAlreadyExists),
+				)...)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testRequestPipelineName,
+			},
+		},
+		{
+			name: "ok with the operation of a concurrent retry that started creating the user-specified cluster",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: testRequestID, ClusterId: testClusterName},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				byRequestID := map[string]string{typev1alpha1.PipelineLabelRequestID: testRequestID}
+				gomock.InOrder(
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, byRequestID).Return(nil, nil),
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(nil, domain.ErrResourceNotFound),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+						typev1alpha1.PipelineLabelCluster: testClusterName,
+					}).Return([]typev1alpha1.Pipeline{
+						{Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhasePending}},
+					}, nil),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, byRequestID).Return([]typev1alpha1.Pipeline{
+						{ObjectMeta: metav1.ObjectMeta{Name: testRequestPipelineName}},
+					}, nil),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testRequestPipelineName,
+			},
+		},
+		{
+			name: "ok with the original operation if the request ID is duplicated",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: testRequestID},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+					typev1alpha1.PipelineLabelRequestID: testRequestID,
+				}).Return([]typev1alpha1.Pipeline{
+					{ObjectMeta: metav1.ObjectMeta{Name: "original-pipeline"}},
+				}, nil)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/original-pipeline",
+			},
+		},
+		{
+			name: "ok with a user-specified cluster ID",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, ClusterId: testClusterName},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(nil, domain.ErrResourceNotFound),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, map[string]string{
+						typev1alpha1.PipelineLabelCluster: testClusterName,
+					}).Return([]typev1alpha1.Pipeline{
+						{Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhaseFailed}},
+					}, nil),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					namegen.EXPECT().New("cluster-create").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, pipeline(map[string]string{
+						typev1alpha1.PipelineLabelCluster: testClusterName,
+					})).Return(nil),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testPipelineName,
+			},
+		},
+		{
+			name: "ok with the cluster ID of a deleted cluster",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, ClusterId: testClusterName},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(nil, domain.ErrResourceNotFound),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, gomock.Any()).Return([]typev1alpha1.Pipeline{
+						{Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhaseSucceeded}},
+						{
+							Spec:   typev1alpha1.PipelineSpec{Operation: typev1alpha1.PipelineOperationDelete},
+							Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhaseSucceeded},
+						},
+					}, nil),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					namegen.EXPECT().New("cluster-create").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, gomock.Any()).Return(nil),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testPipelineName,
+			},
+		},
+		{
+			name: "ok with another generated cluster ID on collision",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append([]any{
+					namegen.EXPECT().NewID().Return("taken"),
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, "taken").Return(&typev1alpha1.KubernetesCluster{}, nil),
+				}, append(generated(client, namegen),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					namegen.EXPECT().New("cluster-create").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, gomock.Any()).Return(nil),
+				)...)...)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testParent + "/operations/" + testPipelineName,
+			},
+		},
+		{
+			name: "already exists if the cluster exists",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, ClusterId: testClusterName},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(&typev1alpha1.KubernetesCluster{}, nil)
+			},
+			code: connect.CodeAlreadyExists,
+		},
+		{
+			name: "already exists if a pipeline is creating the cluster",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, ClusterId: testClusterName},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterName).Return(nil, domain.ErrResourceNotFound),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, gomock.Any()).Return([]typev1alpha1.Pipeline{
+						{Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhasePending}},
+					}, nil),
+				)
+			},
+			code: connect.CodeAlreadyExists,
+		},
+		{
+			name: "invalid argument if cluster ID is not a DNS-1123 label",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, ClusterId: "My_Cluster"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid argument if request ID is not a label value",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: "not a label value"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "unavailable if pipeline lookup by request ID fails",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent, RequestId: testRequestID},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, gomock.Any()).Return(nil, errors.New("failed to list pipelines"))
+			},
+			code: connect.CodeUnavailable,
+		},
+		{
+			name: "invalid argument if parent is not a project",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: "default"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "resource exhausted if the quota is exceeded",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(generated(client, namegen),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(&domain.QuotaError{Subject: testParent, Description: "too many clusters"}),
+				)...)
+			},
+			code: connect.CodeResourceExhausted,
+		},
+		{
+			name: "unavailable if project provisioning fails",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(generated(client, namegen),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(errors.New("failed to create namespace")),
+				)...)
+			},
+			code: connect.CodeUnavailable,
+		},
+		{
+			name: "unavailable if pipeline creation fails",
+			req:  &apiv1alpha1.CreateClusterRequest{Parent: testParent},
+			mock: func(client *Mockclient, namegen *Mocknamegen, quota *Mockquota) {
+				gomock.InOrder(append(generated(client, namegen),
+					quota.EXPECT().CheckCreateCluster(gomock.Any(), testProject).Return(nil),
+					client.EXPECT().EnsureProject(gomock.Any(), testProject).Return(nil),
+					namegen.EXPECT().New(gomock.Any()).Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, gomock.Any()).Return(errors.New("failed to create pipeline")),
+				)...)
+			},
+			code: connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			namegen := NewMocknamegen(ctrl)
+			quota := NewMockquota(ctrl)
+			if tt.mock != nil {
+				tt.mock(client, namegen, quota)
+			}
+			service := New(client, namegen, quota)
+			res, err := service.CreateCluster(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("CreateCluster() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			got := res.Msg
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("CreateCluster() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterService_UpdateCluster(t *testing.T) {
+	testProject := "test-project"
+	testClusterID := "calm-falcon-7c2e"
+	testName := "projects/test-project/clusters/calm-falcon-7c2e"
+	existing := func() *typev1alpha1.KubernetesCluster {
+		return &typev1alpha1.KubernetesCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testClusterID,
+				Annotations: map[string]string{
+					typev1alpha1.KubernetesClusterAnnotationDisplayName: "old name",
+					typev1alpha1.KubernetesClusterAnnotationDescription: "old description",
+				},
+			},
+		}
+	}
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.UpdateClusterRequest
+		mock func(*Mockclient)
+		want *apiv1alpha1.Cluster
+		code connect.Code
+	}
+	tests := []testcase{
+		{
+			name: "ok if display name is updated",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testName, DisplayName: "new name", Description: "ignored"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"display_name"}},
+			},
+			mock: func(client *Mockclient) {
+				updated := existing()
+				updated.Annotations[typev1alpha1.KubernetesClusterAnnotationDisplayName] = "new name"
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(existing(), nil),
+					client.EXPECT().UpdateKubernetesCluster(gomock.Any(), testProject, updated).Return(nil),
+				)
+			},
+			want: &apiv1alpha1.Cluster{Name: testName, DisplayName: "new name", Description: "old description"},
+		},
+		{
+			name: "ok if all fields are updated by a wildcard",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testName, DisplayName: "new name"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+			mock: func(client *Mockclient) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(existing(), nil),
+					client.EXPECT().UpdateKubernetesCluster(gomock.Any(), testProject, gomock.Any()).Return(nil),
+				)
+			},
+			want: &apiv1alpha1.Cluster{Name: testName, DisplayName: "new name"},
+		},
+		{
+			name: "invalid argument if update mask is empty",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster: &apiv1alpha1.Cluster{Name: testName},
+			},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid argument if update mask has a read-only field",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testName},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid argument if cluster name is malformed",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testClusterID},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+			},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "not found if cluster does not exist",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testName},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+			},
+			mock: func(client *Mockclient) {
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(nil, domain.ErrResourceNotFound)
+			},
+			code: connect.CodeNotFound,
+		},
+		{
+			name: "unavailable if update fails",
+			req: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: testName},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description"}},
+			},
+			mock: func(client *Mockclient) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(existing(), nil),
+					client.EXPECT().UpdateKubernetesCluster(gomock.Any(), testProject, gomock.Any()).Return(errors.New("conflict")),
+				)
+			},
+			code: connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			if tt.mock != nil {
+				tt.mock(client)
+			}
+			service := New(client, NewMocknamegen(ctrl), NewMockquota(ctrl))
+			res, err := service.UpdateCluster(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("UpdateCluster() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if !res.Msg.GetDone() {
+				t.Errorf("UpdateCluster() done = false, want true")
+			}
+			var got apiv1alpha1.Cluster
+			if err := res.Msg.GetResponse().UnmarshalTo(&got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, &got, protocmp.Transform()); diff != "" {
+				t.Errorf("UpdateCluster() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterService_GetCluster(t *testing.T) {
+	testProject := "test-project"
+	testClusterID := "calm-falcon-7c2e"
+	testName := "projects/test-project/clusters/calm-falcon-7c2e"
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.GetClusterRequest
+		mock func(*Mockclient)
+		want *apiv1alpha1.Cluster
+		code connect.Code
+	}
+	tests := []testcase{
+		{
+			name: "ok if the cluster exists",
+			req:  &apiv1alpha1.GetClusterRequest{Name: testName},
+			mock: func(client *Mockclient) {
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(&typev1alpha1.KubernetesCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testClusterID,
+						Annotations: map[string]string{
+							typev1alpha1.KubernetesClusterAnnotationDisplayName: "dev",
+							typev1alpha1.KubernetesClusterAnnotationDescription: "for tests",
+						},
+					},
+				}, nil)
+			},
+			want: &apiv1alpha1.Cluster{Name: testName, DisplayName: "dev", Description: "for tests"},
+		},
+		{
+			name: "not found if the cluster does not exist",
+			req:  &apiv1alpha1.GetClusterRequest{Name: testName},
+			mock: func(client *Mockclient) {
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(nil, domain.ErrResourceNotFound)
+			},
+			code: connect.CodeNotFound,
+		},
+		{
+			name: "invalid argument if name is not a cluster",
+			req:  &apiv1alpha1.GetClusterRequest{Name: testClusterID},
+			code: connect.CodeInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			if tt.mock != nil {
+				tt.mock(client)
+			}
+			service := New(client, NewMocknamegen(ctrl), NewMockquota(ctrl))
+			res, err := service.GetCluster(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("GetCluster() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("GetCluster() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterService_ListClusters(t *testing.T) {
+	testProject := "test-project"
+	testParent := "projects/" + testProject
+	kc := func(id, displayName string) typev1alpha1.KubernetesCluster {
+		return typev1alpha1.KubernetesCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: id,
+				Annotations: map[string]string{
+					typev1alpha1.KubernetesClusterAnnotationDisplayName: displayName,
+				},
+			},
+		}
+	}
+	cluster := func(id, displayName string) *apiv1alpha1.Cluster {
+		return &apiv1alpha1.Cluster{Name: testParent + "/clusters/" + id, DisplayName: displayName}
+	}
+	// The clusters are returned in no particular order
+	kcs := func() []typev1alpha1.KubernetesCluster {
+		return []typev1alpha1.KubernetesCluster{kc("c3", "dev"), kc("c1", "dev"), kc("c2", "prod")}
+	}
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.ListClustersRequest
+		mock func(*Mockclient)
+		want *apiv1alpha1.ListClustersResponse
+		code connect.Code
+	}
+	tests := []testcase{
+		{
+			name: "ok with all the clusters ordered by name",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(kcs(), nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{
+				Clusters: []*apiv1alpha1.Cluster{cluster("c1", "dev"), cluster("c2", "prod"), cluster("c3", "dev")},
+			},
+		},
+		{
+			name: "ok with the first page",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, PageSize: 2},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(kcs(), nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{
+				Clusters:      []*apiv1alpha1.Cluster{cluster("c1", "dev"), cluster("c2", "prod")},
+				NextPageToken: newPageToken(cluster("c2", "prod")),
+			},
+		},
+		{
+			name: "ok with the last page",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, PageSize: 2, PageToken: newPageToken(cluster("c2", "prod"))},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(kcs(), nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{
+				Clusters: []*apiv1alpha1.Cluster{cluster("c3", "dev")},
+			},
+		},
+		{
+			name: "ok without the next page token if the page has exactly the rest",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, PageSize: 3},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(kcs(), nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{
+				Clusters: []*apiv1alpha1.Cluster{cluster("c1", "dev"), cluster("c2", "prod"), cluster("c3", "dev")},
+			},
+		},
+		{
+			name: "ok with the clusters that match the filter",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, PageSize: 1, Filter: `display_name = "dev"`},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(kcs(), nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{
+				Clusters:      []*apiv1alpha1.Cluster{cluster("c1", "dev")},
+				NextPageToken: newPageToken(cluster("c1", "dev")),
+			},
+		},
+		{
+			name: "ok without clusters in a project without clusters",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(nil, nil)
+			},
+			want: &apiv1alpha1.ListClustersResponse{},
+		},
+		{
+			name: "invalid argument if the filter is malformed",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, Filter: "display_name = dev"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid argument if the page token is malformed",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent, PageToken: "!"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "invalid argument if parent is not a project",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testProject},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "unavailable if listing fails",
+			req:  &apiv1alpha1.ListClustersRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListKubernetesClusters(gomock.Any(), testProject).Return(nil, errors.New("failed to list"))
+			},
+			code: connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			if tt.mock != nil {
+				tt.mock(client)
+			}
+			service := New(client, NewMocknamegen(ctrl), NewMockquota(ctrl))
+			res, err := service.ListClusters(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("ListClusters() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("ListClusters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterService_DeleteCluster(t *testing.T) {
+	testPipelineName := "test-pipeline"
+	testProject := "test-project"
+	testClusterID := "calm-falcon-7c2e"
+	testName := "projects/test-project/clusters/calm-falcon-7c2e"
+	testLabels := map[string]string{typev1alpha1.PipelineLabelCluster: testClusterID}
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.DeleteClusterRequest
 		mock func(*Mockclient, *Mocknamegen)
 		want *apiv1alpha1.LongRunningOperation
 		code connect.Code
@@ -27,36 +663,71 @@ func TestClusterService_CreateCluster(t *testing.T) {
 	tests := []testcase{
 		{
 			name: "ok if pipeline creation succeeds",
-			req:  &apiv1alpha1.CreateClusterRequest{},
+			req:  &apiv1alpha1.DeleteClusterRequest{Name: testName},
 			mock: func(client *Mockclient, namegen *Mocknamegen) {
 				gomock.InOrder(
-					namegen.EXPECT().New("cluster-create").Return(testPipelineName),
-					namegen.EXPECT().New("kubernetescluster").Return(testClusterName),
-					client.EXPECT().CreatePipeline(gomock.Any(), &typev1alpha1.Pipeline{
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(&typev1alpha1.KubernetesCluster{}, nil),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, testLabels).Return([]typev1alpha1.Pipeline{
+						{Status: typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhaseSucceeded}},
+					}, nil),
+					namegen.EXPECT().New("cluster-delete").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, &typev1alpha1.Pipeline{
 						ObjectMeta: metav1.ObjectMeta{
-							Name:      testPipelineName,
-							Namespace: "default",
+							Name:   testPipelineName,
+							Labels: testLabels,
 						},
 						Spec: typev1alpha1.PipelineSpec{
-							Cluster: typev1alpha1.PipelineClusterSpec{
-								Name: testClusterName,
-							},
+							Cluster:   typev1alpha1.PipelineClusterSpec{Name: testClusterID},
+							Operation: typev1alpha1.PipelineOperationDelete,
 						},
 					}).Return(nil),
 				)
 			},
 			want: &apiv1alpha1.LongRunningOperation{
-				Name: testPipelineName,
+				Name: "projects/test-project/operations/" + testPipelineName,
 			},
 		},
+		{
+			name: "ok with the operation of a pipeline already deleting the cluster",
+			req:  &apiv1alpha1.DeleteClusterRequest{Name: testName},
+			mock: func(client *Mockclient, namegen *Mocknamegen) {
+				gomock.InOrder(
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(&typev1alpha1.KubernetesCluster{}, nil),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, testLabels).Return([]typev1alpha1.Pipeline{
+						{
+							ObjectMeta: metav1.ObjectMeta{Name: "original-pipeline"},
+							Spec:       typev1alpha1.PipelineSpec{Operation: typev1alpha1.PipelineOperationDelete},
+							Status:     typev1alpha1.PipelineStatus{Phase: typev1alpha1.PipelinePhaseRunning},
+						},
+					}, nil),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: "projects/test-project/operations/original-pipeline",
+			},
+		},
+		{
+			name: "not found if the cluster does not exist",
+			req:  &apiv1alpha1.DeleteClusterRequest{Name: testName},
+			mock: func(client *Mockclient, namegen *Mocknamegen) {
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(nil, domain.ErrResourceNotFound)
+			},
+			code: connect.CodeNotFound,
+		},
+		{
+			name: "invalid argument if name is not a cluster",
+			req:  &apiv1alpha1.DeleteClusterRequest{Name: testClusterID},
+			code: connect.CodeInvalidArgument,
+		},
 		{
 			name: "unavailable if pipeline creation fails",
-			req:  &apiv1alpha1.CreateClusterRequest{},
+			req:  &apiv1alpha1.DeleteClusterRequest{Name: testName},
 			mock: func(client *Mockclient, namegen *Mocknamegen) {
 				gomock.InOrder(
-					namegen.EXPECT().New(gomock.Any()).Return(testPipelineName),
-					namegen.EXPECT().New(gomock.Any()).Return(testClusterName),
-					client.EXPECT().CreatePipeline(gomock.Any(), gomock.Any()).Return(errors.New("failed to create pipeline")),
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, testClusterID).Return(&typev1alpha1.KubernetesCluster{}, nil),
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, testLabels).Return(nil, nil),
+					namegen.EXPECT().New("cluster-delete").Return(testPipelineName),
+					client.EXPECT().CreatePipeline(gomock.Any(), testProject, gomock.Any()).Return(errors.New("failed to create pipeline")),
 				)
 			},
 			code: connect.CodeUnavailable,
@@ -70,17 +741,16 @@ func TestClusterService_CreateCluster(t *testing.T) {
 			if tt.mock != nil {
 				tt.mock(client, namegen)
 			}
-			service := New(client, namegen)
-			res, err := service.CreateCluster(context.TODO(), connect.NewRequest(tt.req))
+			service := New(client, namegen, NewMockquota(ctrl))
+			res, err := service.DeleteCluster(context.TODO(), connect.NewRequest(tt.req))
 			if err != nil {
 				if connect.CodeOf(err) != tt.code {
-					t.Errorf("CreateCluster() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+					t.Errorf("DeleteCluster() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
 				}
 				return
 			}
-			got := res.Msg
-			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
-				t.Errorf("CreateCluster() mismatch (-want +got):\n%s", diff)
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("DeleteCluster() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
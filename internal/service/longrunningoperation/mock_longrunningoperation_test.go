@@ -15,6 +15,7 @@ import (
 
 	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	gomock "go.uber.org/mock/gomock"
+	v1 "k8s.io/api/core/v1"
 )
 
 // Mockclient is a mock of client interface.
@@ -42,46 +43,76 @@ func (m *Mockclient) EXPECT() *MockclientMockRecorder {
 }
 
 // GetKubernetesCluster mocks base method.
-func (m *Mockclient) GetKubernetesCluster(ctx context.Context, name, namespace string) (*v1alpha1.KubernetesCluster, error) {
+func (m *Mockclient) GetKubernetesCluster(ctx context.Context, project, name string) (*v1alpha1.KubernetesCluster, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKubernetesCluster", ctx, name, namespace)
+	ret := m.ctrl.Call(m, "GetKubernetesCluster", ctx, project, name)
 	ret0, _ := ret[0].(*v1alpha1.KubernetesCluster)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetKubernetesCluster indicates an expected call of GetKubernetesCluster.
-func (mr *MockclientMockRecorder) GetKubernetesCluster(ctx, name, namespace any) *gomock.Call {
+func (mr *MockclientMockRecorder) GetKubernetesCluster(ctx, project, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesCluster", reflect.TypeOf((*Mockclient)(nil).GetKubernetesCluster), ctx, name, namespace)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesCluster", reflect.TypeOf((*Mockclient)(nil).GetKubernetesCluster), ctx, project, name)
 }
 
 // GetKubernetesClusterConfiguration mocks base method.
-func (m *Mockclient) GetKubernetesClusterConfiguration(ctx context.Context, name, namespace string) (*v1alpha1.KubernetesClusterConfiguration, error) {
+func (m *Mockclient) GetKubernetesClusterConfiguration(ctx context.Context, project, name string) (*v1alpha1.KubernetesClusterConfiguration, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetKubernetesClusterConfiguration", ctx, name, namespace)
+	ret := m.ctrl.Call(m, "GetKubernetesClusterConfiguration", ctx, project, name)
 	ret0, _ := ret[0].(*v1alpha1.KubernetesClusterConfiguration)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetKubernetesClusterConfiguration indicates an expected call of GetKubernetesClusterConfiguration.
-func (mr *MockclientMockRecorder) GetKubernetesClusterConfiguration(ctx, name, namespace any) *gomock.Call {
+func (mr *MockclientMockRecorder) GetKubernetesClusterConfiguration(ctx, project, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesClusterConfiguration", reflect.TypeOf((*Mockclient)(nil).GetKubernetesClusterConfiguration), ctx, name, namespace)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKubernetesClusterConfiguration", reflect.TypeOf((*Mockclient)(nil).GetKubernetesClusterConfiguration), ctx, project, name)
 }
 
 // GetPipeline mocks base method.
-func (m *Mockclient) GetPipeline(ctx context.Context, name, namespace string) (*v1alpha1.Pipeline, error) {
+func (m *Mockclient) GetPipeline(ctx context.Context, project, name string) (*v1alpha1.Pipeline, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPipeline", ctx, name, namespace)
+	ret := m.ctrl.Call(m, "GetPipeline", ctx, project, name)
 	ret0, _ := ret[0].(*v1alpha1.Pipeline)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPipeline indicates an expected call of GetPipeline.
-func (mr *MockclientMockRecorder) GetPipeline(ctx, name, namespace any) *gomock.Call {
+func (mr *MockclientMockRecorder) GetPipeline(ctx, project, name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipeline", reflect.TypeOf((*Mockclient)(nil).GetPipeline), ctx, name, namespace)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipeline", reflect.TypeOf((*Mockclient)(nil).GetPipeline), ctx, project, name)
+}
+
+// ListEvents mocks base method.
+func (m *Mockclient) ListEvents(ctx context.Context, project, kind, name string) ([]v1.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEvents", ctx, project, kind, name)
+	ret0, _ := ret[0].([]v1.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEvents indicates an expected call of ListEvents.
+func (mr *MockclientMockRecorder) ListEvents(ctx, project, kind, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*Mockclient)(nil).ListEvents), ctx, project, kind, name)
+}
+
+// ListPipelines mocks base method.
+func (m *Mockclient) ListPipelines(ctx context.Context, project string, labels map[string]string) ([]v1alpha1.Pipeline, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPipelines", ctx, project, labels)
+	ret0, _ := ret[0].([]v1alpha1.Pipeline)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPipelines indicates an expected call of ListPipelines.
+func (mr *MockclientMockRecorder) ListPipelines(ctx, project, labels any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPipelines", reflect.TypeOf((*Mockclient)(nil).ListPipelines), ctx, project, labels)
 }
@@ -2,25 +2,44 @@
 package longrunningoperation
 
 import (
+	"cmp"
 	"context"
+	"encoding/base64"
 	"errors"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"connectrpc.com/connect"
 	typev1alpha1 "github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
 	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
 	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
 )
 
-const defaultNamespace = "default"
+// maxEvents is the maximum number of recent pipeline events included in the operation metadata.
+const maxEvents = 10
+
+// defaultPageSize is the number of operations ListOperations returns per page if the request does not specify it,
+// and maxPageSize is the maximum number the request can specify.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
 
 type client interface {
-	GetPipeline(ctx context.Context, name, namespace string) (*typev1alpha1.Pipeline, error)
-	GetKubernetesCluster(ctx context.Context, name, namespace string) (*typev1alpha1.KubernetesCluster, error)
-	GetKubernetesClusterConfiguration(ctx context.Context, name, namespace string) (*typev1alpha1.KubernetesClusterConfiguration, error)
+	GetPipeline(ctx context.Context, project, name string) (*typev1alpha1.Pipeline, error)
+	ListPipelines(ctx context.Context, project string, labels map[string]string) ([]typev1alpha1.Pipeline, error)
+	GetKubernetesCluster(ctx context.Context, project, name string) (*typev1alpha1.KubernetesCluster, error)
+	GetKubernetesClusterConfiguration(ctx context.Context, project, name string) (*typev1alpha1.KubernetesClusterConfiguration, error)
+	ListEvents(ctx context.Context, project, kind, name string) ([]corev1.Event, error)
 }
 
 type LongRunningOperationService struct {
@@ -39,19 +58,122 @@ func (l *LongRunningOperationService) GetOperation(
 	req *connect.Request[apiv1alpha1.GetOperationRequest],
 ) (*connect.Response[apiv1alpha1.LongRunningOperation], error) {
 	// Retrieve the pipeline name from the request
-	pipelineName := req.Msg.GetName()
-	pipeline, err := l.client.GetPipeline(ctx, pipelineName, defaultNamespace)
-	if errors.Is(err, domain.ErrResourceNotFound) {
-		return nil, connect.NewError(connect.CodeNotFound, err)
+	name, err := domain.ParseOperationName(req.Msg.GetName())
+	if err != nil {
+		return nil, apierror.InvalidArgument("name", err)
+	}
+	pipeline, err := l.client.GetPipeline(ctx, name.Project, name.Operation)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeOperation, name.String(), err)
+	}
+	lro, err := l.newOperation(ctx, name.Project, pipeline, true)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(lro), nil
+}
+
+// ListOperations returns the operations of the pipelines in the namespace of the project, from the newest to the oldest.
+// Each operation is the same as GetOperation returns but without the events, which cost a request per operation.
+// The page token is the opaque position after the last operation of the previous page,
+// so that an operation created between the pages does not shift the following ones.
+func (l *LongRunningOperationService) ListOperations(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.ListOperationsRequest],
+) (*connect.Response[apiv1alpha1.ListOperationsResponse], error) {
+	project, err := domain.ParseProjectName(req.Msg.GetParent())
+	if err != nil {
+		return nil, apierror.InvalidArgument("parent", err)
+	}
+	after, err := parsePageToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, apierror.InvalidArgument("page_token", err)
+	}
+	pageSize := int(req.Msg.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+	pipelines, err := l.client.ListPipelines(ctx, project, nil)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeProject, req.Msg.GetParent(), err)
+	}
+	slices.SortFunc(pipelines, func(a, b typev1alpha1.Pipeline) int {
+		return positionOf(&a).compare(positionOf(&b))
+	})
+	res := &apiv1alpha1.ListOperationsResponse{}
+	var last position
+	for _, pipeline := range pipelines {
+		if after != nil && positionOf(&pipeline).compare(*after) <= 0 {
+			continue
+		}
+		if len(res.Operations) == pageSize {
+			res.NextPageToken = last.pageToken()
+			break
+		}
+		lro, err := l.newOperation(ctx, project, &pipeline, false)
+		if err != nil {
+			return nil, err
+		}
+		res.Operations = append(res.Operations, lro)
+		last = positionOf(&pipeline)
+	}
+	return connect.NewResponse(res), nil
+}
+
+// position is the position of a pipeline in the order of ListOperations.
+type position struct {
+	created int64
+	name    string
+}
+
+func positionOf(p *typev1alpha1.Pipeline) position {
+	return position{created: p.CreationTimestamp.Unix(), name: p.Name}
+}
+
+// compare orders the positions from the newest to the oldest, and by name if created at the same second.
+func (p position) compare(other position) int {
+	if c := cmp.Compare(other.created, p.created); c != 0 {
+		return c
+	}
+	return strings.Compare(p.name, other.name)
+}
+
+// pageToken returns the page token of the page after the position.
+func (p position) pageToken() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(p.created, 10) + "/" + p.name))
+}
+
+// parsePageToken returns the position after which the page starts, or nil for the first page.
+func parsePageToken(token string) (*position, error) {
+	if token == "" {
+		return nil, nil
 	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeUnavailable, err)
+		return nil, errors.Join(errors.New("invalid page_token"), err)
 	}
+	created, name, ok := strings.Cut(string(b), "/")
+	if !ok || name == "" {
+		return nil, errors.New("invalid page_token")
+	}
+	p := position{name: name}
+	p.created, err = strconv.ParseInt(created, 10, 64)
+	if err != nil {
+		return nil, errors.Join(errors.New("invalid page_token"), err)
+	}
+	return &p, nil
+}
+
+// newOperation converts the pipeline into its operation, with the recent events of the pipeline if events is true.
+// The operation is done with the response once the pipeline has succeeded or failed.
+func (l *LongRunningOperationService) newOperation(ctx context.Context, project string, pipeline *typev1alpha1.Pipeline, events bool) (*apiv1alpha1.LongRunningOperation, error) {
+	name := domain.OperationName{Project: project, Operation: pipeline.Name}
 	// Set the metadata baed on the pipeline
 	metadata := &apiv1alpha1.LongRunningOperation_Pipeline{
 		Namespace: pipeline.Namespace,
 		Spec: &apiv1alpha1.LongRunningOperation_Pipeline_Spec{
-			Name:        pipeline.Spec.Cluster.Name,
+			Name:        domain.ClusterName{Project: name.Project, Cluster: pipeline.Spec.Cluster.Name}.String(),
 			DisplayName: pipeline.Spec.Cluster.DisplayName,
 			Description: pipeline.Spec.Cluster.Description,
 		},
@@ -69,55 +191,111 @@ func (l *LongRunningOperationService) GetOperation(
 			},
 		)
 	}
+	if events {
+		// Events are only diagnostics, so the operation is still returned without them if they cannot be listed
+		list, err := l.client.ListEvents(ctx, name.Project, "Pipeline", pipeline.Name)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to list events of the operation", "error", err, "operation", name.String())
+		} else {
+			metadata.Events = newEvents(list)
+		}
+	}
 	m, err := anypb.New(metadata)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, apierror.Internal(err)
 	}
 	// Set the response based on the pipeline status
 	var r *anypb.Any
-	switch pipeline.Status.Phase {
-	case typev1alpha1.PipelinePhaseSucceeded:
-		// If the pipeline is succeeded, we can return Cluster as the response
-		kc, err := l.client.GetKubernetesCluster(ctx, pipeline.Spec.Cluster.Name, defaultNamespace)
-		if errors.Is(err, domain.ErrResourceNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, err)
-		}
+	switch {
+	case pipeline.Status.Phase == typev1alpha1.PipelinePhaseSucceeded && pipeline.IsDelete():
+		// If the pipeline has deleted the cluster, there is nothing to return but an empty response
+		r, err = anypb.New(&emptypb.Empty{})
 		if err != nil {
-			return nil, connect.NewError(connect.CodeUnavailable, err)
-		}
-		kcc, err := l.client.GetKubernetesClusterConfiguration(ctx, pipeline.Spec.Cluster.Name, defaultNamespace)
-		if errors.Is(err, domain.ErrResourceNotFound) {
-			return nil, connect.NewError(connect.CodeNotFound, err)
+			return nil, apierror.Internal(err)
 		}
+
+	case pipeline.Status.Phase == typev1alpha1.PipelinePhaseSucceeded:
+		// If the pipeline is succeeded, we can return Cluster as the response
+		cluster, err := l.createdCluster(ctx, name.Project, pipeline)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeUnavailable, err)
+			return nil, apierror.From(apierror.ResourceTypeCluster, cluster.GetName(), err)
 		}
-		r, err = anypb.New(newCluster(kc, kcc))
+		r, err = anypb.New(cluster)
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInternal, err)
+			return nil, apierror.Internal(err)
 		}
 
-	case typev1alpha1.PipelinePhaseFailed:
+	case pipeline.Status.Phase == typev1alpha1.PipelinePhaseFailed:
 		// If the pipeline is failed, we can return an empty response
 		r, err = anypb.New(&emptypb.Empty{})
 		if err != nil {
-			return nil, connect.NewError(connect.CodeInternal, err)
+			return nil, apierror.Internal(err)
 		}
 	}
-	lro := &apiv1alpha1.LongRunningOperation{
-		Name:     pipelineName,
+	return &apiv1alpha1.LongRunningOperation{
+		Name:     name.String(),
 		Metadata: m,
 		Response: r,
 		Done:     r != nil,
+	}, nil
+}
+
+// createdCluster returns the cluster the succeeded pipeline has created.
+// If the cluster has been deleted since, it returns the cluster as the pipeline created it.
+func (l *LongRunningOperationService) createdCluster(ctx context.Context, project string, pipeline *typev1alpha1.Pipeline) (*apiv1alpha1.Cluster, error) {
+	created := &apiv1alpha1.Cluster{
+		Name:        domain.ClusterName{Project: project, Cluster: pipeline.Spec.Cluster.Name}.String(),
+		DisplayName: pipeline.Spec.Cluster.DisplayName,
+		Description: pipeline.Spec.Cluster.Description,
 	}
-	return connect.NewResponse(lro), nil
+	kc, err := l.client.GetKubernetesCluster(ctx, project, pipeline.Spec.Cluster.Name)
+	if errors.Is(err, domain.ErrResourceNotFound) {
+		return created, nil
+	}
+	if err != nil {
+		return created, err
+	}
+	kcc, err := l.client.GetKubernetesClusterConfiguration(ctx, project, pipeline.Spec.Cluster.Name)
+	if err != nil && !errors.Is(err, domain.ErrResourceNotFound) {
+		return created, err
+	}
+	return newCluster(project, kc, kcc), nil
 }
 
-func newCluster(kc *typev1alpha1.KubernetesCluster, _ *typev1alpha1.KubernetesClusterConfiguration) *apiv1alpha1.Cluster {
+func newCluster(project string, kc *typev1alpha1.KubernetesCluster, _ *typev1alpha1.KubernetesClusterConfiguration) *apiv1alpha1.Cluster {
 	c := apiv1alpha1.Cluster{
-		Name:        kc.Name,
+		Name:        domain.ClusterName{Project: project, Cluster: kc.Name}.String(),
 		DisplayName: kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDisplayName],
 		Description: kc.Annotations[typev1alpha1.KubernetesClusterAnnotationDescription],
 	}
 	return &c
 }
+
+// newEvents converts the Kubernetes events into the operation metadata events.
+// It returns at most maxEvents events ordered from the newest to the oldest.
+func newEvents(events []corev1.Event) []*apiv1alpha1.LongRunningOperation_Pipeline_Event {
+	events = slices.Clone(events)
+	slices.SortStableFunc(events, func(a, b corev1.Event) int {
+		return lastTimestamp(b).Compare(lastTimestamp(a))
+	})
+	var res []*apiv1alpha1.LongRunningOperation_Pipeline_Event
+	for _, e := range events[:min(len(events), maxEvents)] {
+		res = append(res, &apiv1alpha1.LongRunningOperation_Pipeline_Event{
+			Type:          e.Type,
+			Reason:        e.Reason,
+			Message:       e.Message,
+			Count:         e.Count,
+			LastTimestamp: timestamppb.New(lastTimestamp(e)),
+		})
+	}
+	return res
+}
+
+// lastTimestamp returns the time when the event was last observed.
+// It falls back to the event time for events recorded through the events.k8s.io API.
+func lastTimestamp(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	return e.EventTime.Time
+}
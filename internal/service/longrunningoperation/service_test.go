@@ -2,7 +2,9 @@ package longrunningoperation
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/google/go-cmp/cmp"
@@ -12,12 +14,17 @@ import (
 	gomock "go.uber.org/mock/gomock"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 	testPipelineName := "test-pipeline"
+	testProject := "test-project"
+	testNamespace := "project-" + testProject
+	testOperationName := "projects/" + testProject + "/operations/" + testPipelineName
 	type testcase struct {
 		name string
 		req  *apiv1alpha1.GetOperationRequest
@@ -29,16 +36,17 @@ func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 		return v
 	}
 	now := metav1.Now()
+	earlier := metav1.NewTime(now.Add(-time.Minute))
 	tests := []testcase{
 		{
 			name: "ok if pipeline get succeeds",
-			req:  &apiv1alpha1.GetOperationRequest{Name: testPipelineName},
+			req:  &apiv1alpha1.GetOperationRequest{Name: testOperationName},
 			mock: func(client *Mockclient) {
 				gomock.InOrder(
-					client.EXPECT().GetPipeline(gomock.Any(), testPipelineName, "default").Return(&typev1alpha1.Pipeline{
+					client.EXPECT().GetPipeline(gomock.Any(), testProject, testPipelineName).Return(&typev1alpha1.Pipeline{
 						ObjectMeta: metav1.ObjectMeta{
 							Name:      testPipelineName,
-							Namespace: "default",
+							Namespace: testNamespace,
 						},
 						Spec: typev1alpha1.PipelineSpec{
 							Cluster: typev1alpha1.PipelineClusterSpec{
@@ -52,7 +60,23 @@ func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 							LastSyncedTime: now,
 						},
 					}, nil),
-					client.EXPECT().GetKubernetesCluster(gomock.Any(), "cluster1", "default").Return(&typev1alpha1.KubernetesCluster{
+					client.EXPECT().ListEvents(gomock.Any(), testProject, "Pipeline", testPipelineName).Return([]corev1.Event{
+						{
+							Type:          corev1.EventTypeNormal,
+							Reason:        "KubernetesClusterCreated",
+							Message:       "Created KubernetesCluster cluster1",
+							Count:         1,
+							LastTimestamp: earlier,
+						},
+						{
+							Type:          corev1.EventTypeNormal,
+							Reason:        "KubernetesClusterRunning",
+							Message:       "Phase changed",
+							Count:         1,
+							LastTimestamp: now,
+						},
+					}, nil),
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, "cluster1").Return(&typev1alpha1.KubernetesCluster{
 						ObjectMeta: metav1.ObjectMeta{
 							Name: "cluster1",
 							Annotations: map[string]string{
@@ -61,16 +85,16 @@ func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 							},
 						},
 					}, nil),
-					client.EXPECT().GetKubernetesClusterConfiguration(gomock.Any(), "cluster1", "default").Return(&typev1alpha1.KubernetesClusterConfiguration{}, nil),
+					client.EXPECT().GetKubernetesClusterConfiguration(gomock.Any(), testProject, "cluster1").Return(&typev1alpha1.KubernetesClusterConfiguration{}, nil),
 				)
 			},
 			want: &apiv1alpha1.LongRunningOperation{
-				Name: testPipelineName,
+				Name: testOperationName,
 				Done: true,
 				Metadata: must(anypb.New(&apiv1alpha1.LongRunningOperation_Pipeline{
-					Namespace: "default",
+					Namespace: testNamespace,
 					Spec: &apiv1alpha1.LongRunningOperation_Pipeline_Spec{
-						Name:        "cluster1",
+						Name:        "projects/test-project/clusters/cluster1",
 						DisplayName: "Cluster 1",
 						Description: "desc",
 					},
@@ -78,22 +102,116 @@ func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 						Phase:           string(typev1alpha1.PipelinePhaseSucceeded),
 						LastSynchedTime: timestamppb.New(now.Time),
 					},
+					Events: []*apiv1alpha1.LongRunningOperation_Pipeline_Event{
+						{
+							Type:          corev1.EventTypeNormal,
+							Reason:        "KubernetesClusterRunning",
+							Message:       "Phase changed",
+							Count:         1,
+							LastTimestamp: timestamppb.New(now.Time),
+						},
+						{
+							Type:          corev1.EventTypeNormal,
+							Reason:        "KubernetesClusterCreated",
+							Message:       "Created KubernetesCluster cluster1",
+							Count:         1,
+							LastTimestamp: timestamppb.New(earlier.Time),
+						},
+					},
 				})),
 				Response: must(anypb.New(&apiv1alpha1.Cluster{
-					Name:        "cluster1",
+					Name:        "projects/test-project/clusters/cluster1",
 					DisplayName: "Cluster 1",
 					Description: "desc",
 				})),
 			},
 		},
+		{
+			name: "ok without events if event listing fails",
+			req:  &apiv1alpha1.GetOperationRequest{Name: testOperationName},
+			mock: func(client *Mockclient) {
+				gomock.InOrder(
+					client.EXPECT().GetPipeline(gomock.Any(), testProject, testPipelineName).Return(&typev1alpha1.Pipeline{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testPipelineName,
+							Namespace: testNamespace,
+						},
+						Spec: typev1alpha1.PipelineSpec{
+							Cluster: typev1alpha1.PipelineClusterSpec{Name: "cluster1"},
+						},
+						Status: typev1alpha1.PipelineStatus{
+							Phase:          typev1alpha1.PipelinePhaseRunning,
+							LastSyncedTime: now,
+						},
+					}, nil),
+					client.EXPECT().ListEvents(gomock.Any(), testProject, "Pipeline", testPipelineName).Return(nil, errors.New("forbidden")),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testOperationName,
+				Metadata: must(anypb.New(&apiv1alpha1.LongRunningOperation_Pipeline{
+					Namespace: testNamespace,
+					Spec: &apiv1alpha1.LongRunningOperation_Pipeline_Spec{
+						Name: "projects/test-project/clusters/cluster1",
+					},
+					Status: &apiv1alpha1.LongRunningOperation_Pipeline_Status{
+						Phase:           string(typev1alpha1.PipelinePhaseRunning),
+						LastSynchedTime: timestamppb.New(now.Time),
+					},
+				})),
+			},
+		},
+		{
+			name: "ok with an empty response if a delete pipeline succeeds",
+			req:  &apiv1alpha1.GetOperationRequest{Name: testOperationName},
+			mock: func(client *Mockclient) {
+				gomock.InOrder(
+					client.EXPECT().GetPipeline(gomock.Any(), testProject, testPipelineName).Return(&typev1alpha1.Pipeline{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      testPipelineName,
+							Namespace: testNamespace,
+						},
+						Spec: typev1alpha1.PipelineSpec{
+							Cluster:   typev1alpha1.PipelineClusterSpec{Name: "cluster1"},
+							Operation: typev1alpha1.PipelineOperationDelete,
+						},
+						Status: typev1alpha1.PipelineStatus{
+							Phase:          typev1alpha1.PipelinePhaseSucceeded,
+							LastSyncedTime: now,
+						},
+					}, nil),
+					client.EXPECT().ListEvents(gomock.Any(), testProject, "Pipeline", testPipelineName).Return(nil, nil),
+				)
+			},
+			want: &apiv1alpha1.LongRunningOperation{
+				Name: testOperationName,
+				Done: true,
+				Metadata: must(anypb.New(&apiv1alpha1.LongRunningOperation_Pipeline{
+					Namespace: testNamespace,
+					Spec: &apiv1alpha1.LongRunningOperation_Pipeline_Spec{
+						Name: "projects/test-project/clusters/cluster1",
+					},
+					Status: &apiv1alpha1.LongRunningOperation_Pipeline_Status{
+						Phase:           string(typev1alpha1.PipelinePhaseSucceeded),
+						LastSynchedTime: timestamppb.New(now.Time),
+					},
+				})),
+				Response: must(anypb.New(&emptypb.Empty{})),
+			},
+		},
 		{
 			name: "not found if pipeline does not exist",
-			req:  &apiv1alpha1.GetOperationRequest{Name: testPipelineName},
+			req:  &apiv1alpha1.GetOperationRequest{Name: testOperationName},
 			mock: func(client *Mockclient) {
-				client.EXPECT().GetPipeline(gomock.Any(), testPipelineName, "default").Return(nil, domain.ErrResourceNotFound)
+				client.EXPECT().GetPipeline(gomock.Any(), testProject, testPipelineName).Return(nil, domain.ErrResourceNotFound)
 			},
 			code: connect.CodeNotFound,
 		},
+		{
+			name: "invalid argument if name is not an operation",
+			req:  &apiv1alpha1.GetOperationRequest{Name: testPipelineName},
+			code: connect.CodeInvalidArgument,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -117,3 +235,184 @@ func TestLongRunningOperationService_GetPipeline(t *testing.T) {
 		})
 	}
 }
+
+func TestLongRunningOperationService_ListOperations(t *testing.T) {
+	testProject := "test-project"
+	testParent := "projects/" + testProject
+	testNamespace := "project-" + testProject
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.ListOperationsRequest
+		mock func(*Mockclient)
+		want *apiv1alpha1.ListOperationsResponse
+		code connect.Code
+	}
+	must := func(v *anypb.Any, err error) *anypb.Any {
+		return v
+	}
+	now := metav1.Now()
+	earlier := metav1.NewTime(now.Add(-time.Minute))
+	pipeline := func(name string, created metav1.Time, phase typev1alpha1.PipelinePhase) typev1alpha1.Pipeline {
+		return typev1alpha1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         testNamespace,
+				CreationTimestamp: created,
+			},
+			Spec: typev1alpha1.PipelineSpec{
+				Cluster: typev1alpha1.PipelineClusterSpec{Name: "cluster1", DisplayName: "Cluster 1"},
+			},
+			Status: typev1alpha1.PipelineStatus{
+				Phase:          phase,
+				LastSyncedTime: now,
+			},
+		}
+	}
+	metadata := func(phase typev1alpha1.PipelinePhase) *anypb.Any {
+		return must(anypb.New(&apiv1alpha1.LongRunningOperation_Pipeline{
+			Namespace: testNamespace,
+			Spec: &apiv1alpha1.LongRunningOperation_Pipeline_Spec{
+				Name:        "projects/test-project/clusters/cluster1",
+				DisplayName: "Cluster 1",
+			},
+			Status: &apiv1alpha1.LongRunningOperation_Pipeline_Status{
+				Phase:           string(phase),
+				LastSynchedTime: timestamppb.New(now.Time),
+			},
+		}))
+	}
+	tests := []testcase{
+		{
+			name: "ok with the operations from the newest to the oldest",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return([]typev1alpha1.Pipeline{
+					pipeline("created", earlier, typev1alpha1.PipelinePhaseSucceeded),
+					pipeline("running", now, typev1alpha1.PipelinePhaseRunning),
+				}, nil)
+				// The cluster has been deleted since it was created
+				client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, "cluster1").Return(nil, domain.ErrResourceNotFound)
+			},
+			want: &apiv1alpha1.ListOperationsResponse{
+				Operations: []*apiv1alpha1.LongRunningOperation{
+					{
+						Name:     testParent + "/operations/running",
+						Metadata: metadata(typev1alpha1.PipelinePhaseRunning),
+					},
+					{
+						Name:     testParent + "/operations/created",
+						Done:     true,
+						Metadata: metadata(typev1alpha1.PipelinePhaseSucceeded),
+						Response: must(anypb.New(&apiv1alpha1.Cluster{
+							Name:        "projects/test-project/clusters/cluster1",
+							DisplayName: "Cluster 1",
+						})),
+					},
+				},
+			},
+		},
+		{
+			name: "ok with the first page and the token of the next page",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent, PageSize: 1},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return([]typev1alpha1.Pipeline{
+					pipeline("created", earlier, typev1alpha1.PipelinePhaseFailed),
+					pipeline("running", now, typev1alpha1.PipelinePhaseRunning),
+				}, nil)
+			},
+			want: &apiv1alpha1.ListOperationsResponse{
+				Operations: []*apiv1alpha1.LongRunningOperation{
+					{
+						Name:     testParent + "/operations/running",
+						Metadata: metadata(typev1alpha1.PipelinePhaseRunning),
+					},
+				},
+				NextPageToken: position{created: now.Unix(), name: "running"}.pageToken(),
+			},
+		},
+		{
+			name: "ok with the next page after an operation created between the pages",
+			req: &apiv1alpha1.ListOperationsRequest{
+				Parent:    testParent,
+				PageSize:  1,
+				PageToken: position{created: now.Unix(), name: "running"}.pageToken(),
+			},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return([]typev1alpha1.Pipeline{
+					pipeline("created", earlier, typev1alpha1.PipelinePhaseFailed),
+					pipeline("running", now, typev1alpha1.PipelinePhaseRunning),
+					pipeline("newer", metav1.NewTime(now.Add(time.Minute)), typev1alpha1.PipelinePhaseRunning),
+				}, nil)
+			},
+			want: &apiv1alpha1.ListOperationsResponse{
+				Operations: []*apiv1alpha1.LongRunningOperation{
+					{
+						Name:     testParent + "/operations/created",
+						Done:     true,
+						Metadata: metadata(typev1alpha1.PipelinePhaseFailed),
+						Response: must(anypb.New(&emptypb.Empty{})),
+					},
+				},
+			},
+		},
+		{
+			name: "invalid argument if the page token is malformed",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent, PageToken: "not a token"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "ok without operations in a project without pipelines",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return(nil, nil)
+			},
+			want: &apiv1alpha1.ListOperationsResponse{},
+		},
+		{
+			name: "invalid argument if parent is not a project",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testProject},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "unavailable if listing fails",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return(nil, errors.New("failed to list"))
+			},
+			code: connect.CodeUnavailable,
+		},
+		{
+			name: "unavailable if the cluster of an operation cannot be retrieved",
+			req:  &apiv1alpha1.ListOperationsRequest{Parent: testParent},
+			mock: func(client *Mockclient) {
+				gomock.InOrder(
+					client.EXPECT().ListPipelines(gomock.Any(), testProject, nil).Return([]typev1alpha1.Pipeline{
+						pipeline("created", earlier, typev1alpha1.PipelinePhaseSucceeded),
+					}, nil),
+					client.EXPECT().GetKubernetesCluster(gomock.Any(), testProject, "cluster1").Return(nil, errors.New("failed to get")),
+				)
+			},
+			code: connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			client := NewMockclient(ctrl)
+			if tt.mock != nil {
+				tt.mock(client)
+			}
+			service := New(client)
+			res, err := service.ListOperations(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("ListOperations() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("ListOperations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nokamoto/kaas-operator-prototype/internal/service/quota (interfaces: tracker)
+//
+// Generated by this command:
+//
+//	mockgen -package quota -destination mock_quota_test.go . tracker
+//
+
+// Package quota is a generated GoMock package.
+package quota
+
+import (
+	context "context"
+	reflect "reflect"
+
+	quota "github.com/nokamoto/kaas-operator-prototype/internal/quota"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mocktracker is a mock of tracker interface.
+type Mocktracker struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrackerMockRecorder
+	isgomock struct{}
+}
+
+// MocktrackerMockRecorder is the mock recorder for Mocktracker.
+type MocktrackerMockRecorder struct {
+	mock *Mocktracker
+}
+
+// NewMocktracker creates a new mock instance.
+func NewMocktracker(ctrl *gomock.Controller) *Mocktracker {
+	mock := &Mocktracker{ctrl: ctrl}
+	mock.recorder = &MocktrackerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mocktracker) EXPECT() *MocktrackerMockRecorder {
+	return m.recorder
+}
+
+// Limits mocks base method.
+func (m *Mocktracker) Limits() quota.Limits {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits")
+	ret0, _ := ret[0].(quota.Limits)
+	return ret0
+}
+
+// Limits indicates an expected call of Limits.
+func (mr *MocktrackerMockRecorder) Limits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*Mocktracker)(nil).Limits))
+}
+
+// Usage mocks base method.
+func (m *Mocktracker) Usage(ctx context.Context, project string) (quota.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, project)
+	ret0, _ := ret[0].(quota.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MocktrackerMockRecorder) Usage(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*Mocktracker)(nil).Usage), ctx, project)
+}
@@ -0,0 +1,52 @@
+//go:generate mockgen -package quota -destination mock_quota_test.go . tracker
+package quota
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/domain"
+	quotapkg "github.com/nokamoto/kaas-operator-prototype/internal/quota"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1/v1alpha1connect"
+)
+
+type tracker interface {
+	Limits() quotapkg.Limits
+	Usage(ctx context.Context, project string) (quotapkg.Usage, error)
+}
+
+type QuotaService struct {
+	v1alpha1connect.UnimplementedQuotaServiceHandler
+	tracker tracker
+}
+
+func New(tracker tracker) *QuotaService {
+	return &QuotaService{
+		tracker: tracker,
+	}
+}
+
+// GetQuota returns the quota limits of the project and its current usage.
+func (q *QuotaService) GetQuota(
+	ctx context.Context,
+	req *connect.Request[apiv1alpha1.GetQuotaRequest],
+) (*connect.Response[apiv1alpha1.Quota], error) {
+	project, err := domain.ParseQuotaName(req.Msg.GetName())
+	if err != nil {
+		return nil, apierror.InvalidArgument("name", err)
+	}
+	usage, err := q.tracker.Usage(ctx, project)
+	if err != nil {
+		return nil, apierror.From(apierror.ResourceTypeProject, domain.ProjectName(project), err)
+	}
+	limits := q.tracker.Limits()
+	return connect.NewResponse(&apiv1alpha1.Quota{
+		Name:                 domain.QuotaName(project),
+		MaxClusters:          int32(limits.MaxClusters),
+		Clusters:             int32(usage.Clusters),
+		MaxPendingOperations: int32(limits.MaxPendingOperations),
+		PendingOperations:    int32(usage.PendingOperations),
+	}), nil
+}
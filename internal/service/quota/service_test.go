@@ -0,0 +1,76 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	quotapkg "github.com/nokamoto/kaas-operator-prototype/internal/quota"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestQuotaService_GetQuota(t *testing.T) {
+	type testcase struct {
+		name string
+		req  *apiv1alpha1.GetQuotaRequest
+		mock func(*Mocktracker)
+		want *apiv1alpha1.Quota
+		code connect.Code
+	}
+	tests := []testcase{
+		{
+			name: "ok",
+			req:  &apiv1alpha1.GetQuotaRequest{Name: "projects/p1/quota"},
+			mock: func(q *Mocktracker) {
+				gomock.InOrder(
+					q.EXPECT().Usage(gomock.Any(), "p1").Return(quotapkg.Usage{Clusters: 2, PendingOperations: 1}, nil),
+					q.EXPECT().Limits().Return(quotapkg.Limits{MaxClusters: 10, MaxPendingOperations: 5}),
+				)
+			},
+			want: &apiv1alpha1.Quota{
+				Name:                 "projects/p1/quota",
+				MaxClusters:          10,
+				Clusters:             2,
+				MaxPendingOperations: 5,
+				PendingOperations:    1,
+			},
+		},
+		{
+			name: "invalid argument if name is not a quota",
+			req:  &apiv1alpha1.GetQuotaRequest{Name: "projects/p1"},
+			code: connect.CodeInvalidArgument,
+		},
+		{
+			name: "unavailable if usage fails",
+			req:  &apiv1alpha1.GetQuotaRequest{Name: "projects/p1/quota"},
+			mock: func(q *Mocktracker) {
+				q.EXPECT().Usage(gomock.Any(), "p1").Return(quotapkg.Usage{}, errors.New("failed to list"))
+			},
+			code: connect.CodeUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			q := NewMocktracker(ctrl)
+			if tt.mock != nil {
+				tt.mock(q)
+			}
+			service := New(q)
+			res, err := service.GetQuota(context.TODO(), connect.NewRequest(tt.req))
+			if err != nil {
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("GetQuota() error = %v, wantCode %v", connect.CodeOf(err), tt.code)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, res.Msg, protocmp.Transform()); diff != "" {
+				t.Errorf("GetQuota() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Package tracing configures OpenTelemetry tracing and propagates trace contexts through Kubernetes resources.
+//
+// The trace context of an API request is stored in the annotations of the resources it creates,
+// so that the controllers can continue the same trace when they reconcile those resources.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ExporterEnv is the environment variable to select the trace exporter.
+	// It supports "otlp" and "stdout". Tracing is disabled if it is not set.
+	ExporterEnv = "OTEL_TRACES_EXPORTER"
+
+	// AnnotationPrefix is the prefix of the annotations that hold the propagated trace context.
+	AnnotationPrefix = "nokamoto.github.com/trace."
+
+	tracerName = "github.com/nokamoto/kaas-operator-prototype"
+)
+
+// Setup configures the global tracer provider and propagator for the given service.
+// The OTLP exporter is configured by the standard OTEL_EXPORTER_OTLP_* environment variables.
+// It returns a function to flush and stop the tracer provider.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch v := os.Getenv(ExporterEnv); v {
+	case "otlp":
+		exporter, err = otlptracegrpc.New(ctx)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case "", "none":
+		return func(context.Context) error { return nil }, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, must be one of otlp, stdout, none", v)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Inject stores the trace context of ctx in the annotations of the given object.
+func Inject(ctx context.Context, obj client.Object) {
+	otel.GetTextMapPropagator().Inject(ctx, annotationCarrier{obj: obj})
+}
+
+// Extract returns a copy of ctx with the trace context stored in the annotations of the given object.
+func Extract(ctx context.Context, obj client.Object) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, annotationCarrier{obj: obj})
+}
+
+// Start starts a span for reconciling the given object.
+// The span continues the trace propagated through the annotations of the object if any.
+func Start(ctx context.Context, obj client.Object, name string) (context.Context, trace.Span) {
+	ctx = Extract(ctx, obj)
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(
+		semconv.K8SNamespaceName(obj.GetNamespace()),
+		attribute.String("k8s.object.name", obj.GetName()),
+	))
+}
+
+// End ends the span and records the error if it is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// annotationCarrier adapts the annotations of an object to propagation.TextMapCarrier.
+type annotationCarrier struct {
+	obj client.Object
+}
+
+func (c annotationCarrier) Get(key string) string {
+	return c.obj.GetAnnotations()[AnnotationPrefix+key]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	annotations := c.obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationPrefix+key] = value
+	c.obj.SetAnnotations(annotations)
+}
+
+func (c annotationCarrier) Keys() []string {
+	var keys []string
+	for k := range c.obj.GetAnnotations() {
+		if key, ok := strings.CutPrefix(k, AnnotationPrefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nokamoto/kaas-operator-prototype/api/crd/v1alpha1"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStart(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, parent := tp.Tracer("test").Start(context.TODO(), "CreateCluster")
+	pipeline := &v1alpha1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipeline",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"other": "value",
+			},
+		},
+	}
+	Inject(ctx, pipeline)
+	parent.End()
+
+	if _, ok := pipeline.Annotations[AnnotationPrefix+"traceparent"]; !ok {
+		t.Fatalf("Inject() annotations = %v, want traceparent", pipeline.Annotations)
+	}
+	if got := pipeline.Annotations["other"]; got != "value" {
+		t.Errorf("Inject() overwrote other annotation = %q", got)
+	}
+
+	_, span := Start(context.TODO(), pipeline, "PipelineReconciler.Reconcile")
+	End(span, errors.New("failed"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	got := spans[1]
+	if got.SpanContext.TraceID() != parent.SpanContext().TraceID() {
+		t.Errorf("Start() trace ID = %v, want %v", got.SpanContext.TraceID(), parent.SpanContext().TraceID())
+	}
+	if got.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("Start() parent span ID = %v, want %v", got.Parent.SpanID(), parent.SpanContext().SpanID())
+	}
+	if got.Status.Code != codes.Error {
+		t.Errorf("End() status = %v, want %v", got.Status.Code, codes.Error)
+	}
+}
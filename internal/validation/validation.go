@@ -0,0 +1,58 @@
+// Package validation validates API requests with the protovalidate constraints declared in the protos.
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/nokamoto/kaas-operator-prototype/internal/service/apierror"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewInterceptor returns an interceptor that validates every request message before it reaches the handler.
+// Invalid requests fail with CodeInvalidArgument and a BadRequest detail listing the field violations.
+func NewInterceptor() (connect.UnaryInterceptorFunc, error) {
+	validator, err := protovalidate.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create validator: %w", err)
+	}
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if req.Spec().IsClient {
+				return next(ctx, req)
+			}
+			msg, ok := req.Any().(proto.Message)
+			if !ok {
+				return next(ctx, req)
+			}
+			if err := Validate(validator, msg); err != nil {
+				return nil, err
+			}
+			return next(ctx, req)
+		}
+	}, nil
+}
+
+// Validate validates the message and converts the violations into an InvalidArgument error.
+// Errors other than violations, such as a broken constraint expression, are reported as Internal.
+func Validate(validator protovalidate.Validator, msg proto.Message) error {
+	err := validator.Validate(msg)
+	if err == nil {
+		return nil
+	}
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		return apierror.Internal(err)
+	}
+	var violations []apierror.FieldViolation
+	for _, v := range verr.Violations {
+		violations = append(violations, apierror.FieldViolation{
+			Field:       protovalidate.FieldPathString(v.Proto.GetField()),
+			Description: v.Proto.GetMessage(),
+		})
+	}
+	return apierror.InvalidArguments(err, violations...)
+}
@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"testing"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	apiv1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  proto.Message
+		want []string
+	}{
+		{
+			name: "valid create",
+			msg: &apiv1alpha1.CreateClusterRequest{
+				Parent:    "projects/p1",
+				Cluster:   &apiv1alpha1.Cluster{DisplayName: "test"},
+				ClusterId: "c1",
+				RequestId: "8f1c2a4e-55b1-4c1d-9f5a-0b8e2c1d3f4a",
+			},
+		},
+		{
+			name: "create without cluster",
+			msg:  &apiv1alpha1.CreateClusterRequest{Parent: "projects/p1"},
+			want: []string{"cluster"},
+		},
+		{
+			name: "create with invalid fields",
+			msg: &apiv1alpha1.CreateClusterRequest{
+				Parent:    "projects/P1",
+				Cluster:   &apiv1alpha1.Cluster{},
+				ClusterId: "1c",
+				RequestId: "-",
+			},
+			want: []string{"parent", "request_id", "cluster_id"},
+		},
+		{
+			name: "update without name and mask",
+			msg: &apiv1alpha1.UpdateClusterRequest{
+				Cluster: &apiv1alpha1.Cluster{},
+			},
+			want: []string{"cluster", "update_mask"},
+		},
+		{
+			name: "valid update",
+			msg: &apiv1alpha1.UpdateClusterRequest{
+				Cluster:    &apiv1alpha1.Cluster{Name: "projects/p1/clusters/c1"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"*"}},
+			},
+		},
+		{
+			name: "operation in wrong collection",
+			msg:  &apiv1alpha1.GetOperationRequest{Name: "projects/p1/clusters/c1"},
+			want: []string{"name"},
+		},
+	}
+	validator, err := protovalidate.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(validator, tt.msg)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if code := connect.CodeOf(err); code != connect.CodeInvalidArgument {
+				t.Fatalf("Validate() code = %v, want %v", code, connect.CodeInvalidArgument)
+			}
+			var got []string
+			for _, d := range err.(*connect.Error).Details() {
+				v, err := d.Value()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if req, ok := v.(*errdetails.BadRequest); ok {
+					for _, f := range req.GetFieldViolations() {
+						got = append(got, f.GetField())
+					}
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("field violations mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -63,12 +63,18 @@ func (Build) Buf() error {
 
 // Mock generates the mock for gRPC client interfaces.
 func (Build) Mock() error {
-	s, err := sh.Output("mockgen", "./pkg/api/proto/v1alpha1/v1alpha1connect", "ClusterServiceClient")
-	if err != nil {
-		return fmt.Errorf("failed to generate mock: %w", err)
+	mocks := map[string]string{
+		"ClusterServiceClient":              "internal/mock/mock_v1alpha1connect/cluster.go",
+		"LongRunningOperationServiceClient": "internal/mock/mock_v1alpha1connect/longrunningoperation.go",
 	}
-	if err := os.WriteFile("internal/mock/mock_v1alpha1connect/cluster.go", []byte(s), 0o644); err != nil {
-		return fmt.Errorf("failed to write mock file: %w", err)
+	for iface, file := range mocks {
+		s, err := sh.Output("mockgen", "./pkg/api/proto/v1alpha1/v1alpha1connect", iface)
+		if err != nil {
+			return fmt.Errorf("failed to generate mock: %w", err)
+		}
+		if err := os.WriteFile(file, []byte(s), 0o644); err != nil {
+			return fmt.Errorf("failed to write mock file: %w", err)
+		}
 	}
 	return nil
 }
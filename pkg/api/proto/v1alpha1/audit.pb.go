@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/proto/v1alpha1/audit.proto
+
+package v1alpha1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditEvent records a mutating call to the API and its outcome.
+type AuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// time is when the call completed.
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// subject is the authenticated caller qualified by its authentication method, such as `jwt:alice`.
+	// It is empty if authentication is disabled.
+	Subject string `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	// procedure is the called procedure, e.g. `/api.proto.v1alpha1.ClusterService/CreateCluster`.
+	Procedure string `protobuf:"bytes,3,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	// project is the project ID of the resource the call targets.
+	Project string `protobuf:"bytes,4,opt,name=project,proto3" json:"project,omitempty"`
+	// request is the request payload with sensitive fields redacted.
+	Request *structpb.Struct `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"`
+	// operation is the resource name of the long-running operation started by the call, if any.
+	Operation string `protobuf:"bytes,6,opt,name=operation,proto3" json:"operation,omitempty"`
+	// pipeline is the name of the Pipeline resource created by the call, if any.
+	Pipeline string `protobuf:"bytes,7,opt,name=pipeline,proto3" json:"pipeline,omitempty"`
+	// code is "ok" if the call succeeded, or the error code otherwise.
+	Code string `protobuf:"bytes,8,opt,name=code,proto3" json:"code,omitempty"`
+	// message is the error message if the call failed.
+	Message       string `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequest() *structpb.Struct {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetPipeline() string {
+	if x != nil {
+		return x.Pipeline
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The project to list the audit events of, in the format `projects/{project}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// page_size is the maximum number of events to return. Defaults to 100.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAuditEventsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A list of audit events, newest first.
+	Events        []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_api_proto_v1alpha1_audit_proto protoreflect.FileDescriptor
+
+const file_api_proto_v1alpha1_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/proto/v1alpha1/audit.proto\x12\x12api.proto.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x02\n" +
+	"\n" +
+	"AuditEvent\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1c\n" +
+	"\tprocedure\x18\x03 \x01(\tR\tprocedure\x12\x18\n" +
+	"\aproject\x18\x04 \x01(\tR\aproject\x121\n" +
+	"\arequest\x18\x05 \x01(\v2\x17.google.protobuf.StructR\arequest\x12\x1c\n" +
+	"\toperation\x18\x06 \x01(\tR\toperation\x12\x1a\n" +
+	"\bpipeline\x18\a \x01(\tR\bpipeline\x12\x12\n" +
+	"\x04code\x18\b \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"\x8c\x01\n" +
+	"\x16ListAuditEventsRequest\x12L\n" +
+	"\x06parent\x18\x01 \x01(\tB4\xbaH1\xc8\x01\x01r,\x18@2(^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x06parent\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\"Q\n" +
+	"\x17ListAuditEventsResponse\x126\n" +
+	"\x06events\x18\x01 \x03(\v2\x1e.api.proto.v1alpha1.AuditEventR\x06events2\x7f\n" +
+	"\fAuditService\x12o\n" +
+	"\x0fListAuditEvents\x12*.api.proto.v1alpha1.ListAuditEventsRequest\x1a+.api.proto.v1alpha1.ListAuditEventsResponse\"\x03\x90\x02\x01BMZKgithub.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1;v1alpha1b\x06proto3"
+
+var (
+	file_api_proto_v1alpha1_audit_proto_rawDescOnce sync.Once
+	file_api_proto_v1alpha1_audit_proto_rawDescData []byte
+)
+
+func file_api_proto_v1alpha1_audit_proto_rawDescGZIP() []byte {
+	file_api_proto_v1alpha1_audit_proto_rawDescOnce.Do(func() {
+		file_api_proto_v1alpha1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_audit_proto_rawDesc), len(file_api_proto_v1alpha1_audit_proto_rawDesc)))
+	})
+	return file_api_proto_v1alpha1_audit_proto_rawDescData
+}
+
+var file_api_proto_v1alpha1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_proto_v1alpha1_audit_proto_goTypes = []any{
+	(*AuditEvent)(nil),              // 0: api.proto.v1alpha1.AuditEvent
+	(*ListAuditEventsRequest)(nil),  // 1: api.proto.v1alpha1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil), // 2: api.proto.v1alpha1.ListAuditEventsResponse
+	(*timestamppb.Timestamp)(nil),   // 3: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),         // 4: google.protobuf.Struct
+}
+var file_api_proto_v1alpha1_audit_proto_depIdxs = []int32{
+	3, // 0: api.proto.v1alpha1.AuditEvent.time:type_name -> google.protobuf.Timestamp
+	4, // 1: api.proto.v1alpha1.AuditEvent.request:type_name -> google.protobuf.Struct
+	0, // 2: api.proto.v1alpha1.ListAuditEventsResponse.events:type_name -> api.proto.v1alpha1.AuditEvent
+	1, // 3: api.proto.v1alpha1.AuditService.ListAuditEvents:input_type -> api.proto.v1alpha1.ListAuditEventsRequest
+	2, // 4: api.proto.v1alpha1.AuditService.ListAuditEvents:output_type -> api.proto.v1alpha1.ListAuditEventsResponse
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_v1alpha1_audit_proto_init() }
+func file_api_proto_v1alpha1_audit_proto_init() {
+	if File_api_proto_v1alpha1_audit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_audit_proto_rawDesc), len(file_api_proto_v1alpha1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_v1alpha1_audit_proto_goTypes,
+		DependencyIndexes: file_api_proto_v1alpha1_audit_proto_depIdxs,
+		MessageInfos:      file_api_proto_v1alpha1_audit_proto_msgTypes,
+	}.Build()
+	File_api_proto_v1alpha1_audit_proto = out.File
+	file_api_proto_v1alpha1_audit_proto_goTypes = nil
+	file_api_proto_v1alpha1_audit_proto_depIdxs = nil
+}
@@ -11,9 +11,10 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -25,16 +26,18 @@ const (
 
 type Cluster struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The unique identifier for the cluster.
+	// Required. The resource name of the cluster in the format `projects/{project}/clusters/{cluster}`.
 	// This field is read-only and is set by the system.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// display_name is a human-readable name for the cluster.
 	// It can be used for display purposes in user interfaces.
 	// This field is optional and can be set by the user.
+	// It must be at most 128 characters.
 	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
 	// description provides additional information about the cluster.
 	// It can be used to describe the purpose or configuration of the cluster.
 	// This field is optional and can be set by the user.
+	// It must be at most 1024 characters.
 	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -94,7 +97,19 @@ func (x *Cluster) GetDescription() string {
 type CreateClusterRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Required. The cluster to create.
-	Cluster       *Cluster `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Cluster *Cluster `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// Required. The project to create the cluster in, in the format `projects/{project}`.
+	Parent string `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Optional. A unique identifier of the request, such as a UUID, to make retries idempotent.
+	// A request with the same request_id as a previous one in the project returns the original
+	// LongRunningOperation instead of creating another cluster.
+	// It must be at most 63 characters of alphanumerics, '-', '_' or '.'.
+	RequestId string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// Optional. The ID of the cluster, which becomes the last segment of its resource name.
+	// It must be 1 to 63 characters of lowercase letters, digits and '-', start with a letter
+	// and end with a letter or digit. The request fails with ALREADY_EXISTS if the ID is in use in the project.
+	// If empty, a short human-readable ID such as `calm-falcon-7c2e` is generated.
+	ClusterId     string `protobuf:"bytes,4,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -136,9 +151,31 @@ func (x *CreateClusterRequest) GetCluster() *Cluster {
 	return nil
 }
 
+func (x *CreateClusterRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *CreateClusterRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *CreateClusterRequest) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
 type GetClusterRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The name of the cluster to retrieve.
+	// Required. The resource name of the cluster to retrieve.
+	// Format: `projects/{project}/clusters/{cluster}`.
 	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -181,17 +218,92 @@ func (x *GetClusterRequest) GetName() string {
 	return ""
 }
 
+type ListClustersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The project to list the clusters of, in the format `projects/{project}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Optional. The maximum number of clusters to return. The server may return fewer.
+	// If 0, the server chooses the number.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. The next_page_token of a previous response to retrieve the next page.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Optional. An expression that filters the clusters to return, such as `display_name = "dev"`.
+	Filter        string `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClustersRequest) Reset() {
+	*x = ListClustersRequest{}
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClustersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClustersRequest) ProtoMessage() {}
+
+func (x *ListClustersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClustersRequest.ProtoReflect.Descriptor instead.
+func (*ListClustersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListClustersRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListClustersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListClustersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListClustersRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
 type ListClustersResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// A list of clusters.
-	Clusters      []*Cluster `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	Clusters []*Cluster `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	// A token to retrieve the next page, or empty if there are no more clusters.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListClustersResponse) Reset() {
 	*x = ListClustersResponse{}
-	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[3]
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -203,7 +315,7 @@ func (x *ListClustersResponse) String() string {
 func (*ListClustersResponse) ProtoMessage() {}
 
 func (x *ListClustersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[3]
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,7 +328,7 @@ func (x *ListClustersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListClustersResponse.ProtoReflect.Descriptor instead.
 func (*ListClustersResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ListClustersResponse) GetClusters() []*Cluster {
@@ -226,9 +338,71 @@ func (x *ListClustersResponse) GetClusters() []*Cluster {
 	return nil
 }
 
+func (x *ListClustersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateClusterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The cluster to update. Its name identifies the cluster.
+	Cluster *Cluster `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	// Required. The fields of the cluster to update: `display_name` and `description`, or `*` for all of them.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateClusterRequest) Reset() {
+	*x = UpdateClusterRequest{}
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateClusterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateClusterRequest) ProtoMessage() {}
+
+func (x *UpdateClusterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateClusterRequest.ProtoReflect.Descriptor instead.
+func (*UpdateClusterRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateClusterRequest) GetCluster() *Cluster {
+	if x != nil {
+		return x.Cluster
+	}
+	return nil
+}
+
+func (x *UpdateClusterRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteClusterRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The name of the cluster to delete.
+	// Required. The resource name of the cluster to delete.
+	// Format: `projects/{project}/clusters/{cluster}`.
 	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -236,7 +410,7 @@ type DeleteClusterRequest struct {
 
 func (x *DeleteClusterRequest) Reset() {
 	*x = DeleteClusterRequest{}
-	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[4]
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -248,7 +422,7 @@ func (x *DeleteClusterRequest) String() string {
 func (*DeleteClusterRequest) ProtoMessage() {}
 
 func (x *DeleteClusterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[4]
+	mi := &file_api_proto_v1alpha1_cluster_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -261,7 +435,7 @@ func (x *DeleteClusterRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteClusterRequest.ProtoReflect.Descriptor instead.
 func (*DeleteClusterRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_v1alpha1_cluster_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteClusterRequest) GetName() string {
@@ -275,24 +449,42 @@ var File_api_proto_v1alpha1_cluster_proto protoreflect.FileDescriptor
 
 const file_api_proto_v1alpha1_cluster_proto_rawDesc = "" +
 	"\n" +
-	" api/proto/v1alpha1/cluster.proto\x12\x12api.proto.v1alpha1\x1a-api/proto/v1alpha1/longrunningoperation.proto\x1a\x1bgoogle/protobuf/empty.proto\"b\n" +
-	"\aCluster\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
-	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\"M\n" +
-	"\x14CreateClusterRequest\x125\n" +
-	"\acluster\x18\x01 \x01(\v2\x1b.api.proto.v1alpha1.ClusterR\acluster\"'\n" +
-	"\x11GetClusterRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"O\n" +
+	" api/proto/v1alpha1/cluster.proto\x12\x12api.proto.v1alpha1\x1a-api/proto/v1alpha1/longrunningoperation.proto\x1a\x1bbuf/validate/validate.proto\x1a google/protobuf/field_mask.proto\"\xd4\x01\n" +
+	"\aCluster\x12p\n" +
+	"\x04name\x18\x01 \x01(\tB\\\xbaHY\xd8\x01\x01rT\x18\x89\x012O^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?/clusters/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x04name\x12+\n" +
+	"\fdisplay_name\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x01R\vdisplayName\x12*\n" +
+	"\vdescription\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\vdescription\"\xc3\x02\n" +
+	"\x14CreateClusterRequest\x12=\n" +
+	"\acluster\x18\x01 \x01(\v2\x1b.api.proto.v1alpha1.ClusterB\x06\xbaH\x03\xc8\x01\x01R\acluster\x12L\n" +
+	"\x06parent\x18\x02 \x01(\tB4\xbaH1\xc8\x01\x01r,\x18@2(^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x06parent\x12U\n" +
+	"\n" +
+	"request_id\x18\x03 \x01(\tB6\xbaH3\xd8\x01\x01r.\x18?2*^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$R\trequestId\x12G\n" +
+	"\n" +
+	"cluster_id\x18\x04 \x01(\tB(\xbaH%\xd8\x01\x01r \x18?2\x1c^[a-z]([-a-z0-9]*[a-z0-9])?$R\tclusterId\"\x85\x01\n" +
+	"\x11GetClusterRequest\x12p\n" +
+	"\x04name\x18\x01 \x01(\tB\\\xbaHY\xc8\x01\x01rT\x18\x89\x012O^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?/clusters/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x04name\"\xca\x01\n" +
+	"\x13ListClustersRequest\x12L\n" +
+	"\x06parent\x18\x01 \x01(\tB4\xbaH1\xc8\x01\x01r,\x18@2(^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x06parent\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12 \n" +
+	"\x06filter\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06filter\"w\n" +
 	"\x14ListClustersResponse\x127\n" +
-	"\bclusters\x18\x01 \x03(\v2\x1b.api.proto.v1alpha1.ClusterR\bclusters\"*\n" +
-	"\x14DeleteClusterRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name2\xfe\x02\n" +
+	"\bclusters\x18\x01 \x03(\v2\x1b.api.proto.v1alpha1.ClusterR\bclusters\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd7\x01\n" +
+	"\x14UpdateClusterRequest\x12z\n" +
+	"\acluster\x18\x01 \x01(\v2\x1b.api.proto.v1alpha1.ClusterBC\xbaH@\xba\x01:\n" +
+	"\x15cluster.name_required\x12\x10name is required\x1a\x0fthis.name != ''\xc8\x01\x01R\acluster\x12C\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"updateMask\"\x88\x01\n" +
+	"\x14DeleteClusterRequest\x12p\n" +
+	"\x04name\x18\x01 \x01(\tB\\\xbaHY\xc8\x01\x01rT\x18\x89\x012O^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?/clusters/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x04name2\xfe\x03\n" +
 	"\x0eClusterService\x12c\n" +
-	"\rCreateCluster\x12(.api.proto.v1alpha1.CreateClusterRequest\x1a(.api.proto.v1alpha1.LongRunningOperation\x12P\n" +
+	"\rCreateCluster\x12(.api.proto.v1alpha1.CreateClusterRequest\x1a(.api.proto.v1alpha1.LongRunningOperation\x12U\n" +
 	"\n" +
-	"GetCluster\x12%.api.proto.v1alpha1.GetClusterRequest\x1a\x1b.api.proto.v1alpha1.Cluster\x12P\n" +
-	"\fListClusters\x12\x16.google.protobuf.Empty\x1a(.api.proto.v1alpha1.ListClustersResponse\x12c\n" +
+	"GetCluster\x12%.api.proto.v1alpha1.GetClusterRequest\x1a\x1b.api.proto.v1alpha1.Cluster\"\x03\x90\x02\x01\x12f\n" +
+	"\fListClusters\x12'.api.proto.v1alpha1.ListClustersRequest\x1a(.api.proto.v1alpha1.ListClustersResponse\"\x03\x90\x02\x01\x12c\n" +
+	"\rUpdateCluster\x12(.api.proto.v1alpha1.UpdateClusterRequest\x1a(.api.proto.v1alpha1.LongRunningOperation\x12c\n" +
 	"\rDeleteCluster\x12(.api.proto.v1alpha1.DeleteClusterRequest\x1a(.api.proto.v1alpha1.LongRunningOperationBMZKgithub.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1;v1alpha1b\x06proto3"
 
 var (
@@ -307,32 +499,38 @@ func file_api_proto_v1alpha1_cluster_proto_rawDescGZIP() []byte {
 	return file_api_proto_v1alpha1_cluster_proto_rawDescData
 }
 
-var file_api_proto_v1alpha1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_proto_v1alpha1_cluster_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_api_proto_v1alpha1_cluster_proto_goTypes = []any{
-	(*Cluster)(nil),              // 0: api.proto.v1alpha1.Cluster
-	(*CreateClusterRequest)(nil), // 1: api.proto.v1alpha1.CreateClusterRequest
-	(*GetClusterRequest)(nil),    // 2: api.proto.v1alpha1.GetClusterRequest
-	(*ListClustersResponse)(nil), // 3: api.proto.v1alpha1.ListClustersResponse
-	(*DeleteClusterRequest)(nil), // 4: api.proto.v1alpha1.DeleteClusterRequest
-	(*emptypb.Empty)(nil),        // 5: google.protobuf.Empty
-	(*LongRunningOperation)(nil), // 6: api.proto.v1alpha1.LongRunningOperation
+	(*Cluster)(nil),               // 0: api.proto.v1alpha1.Cluster
+	(*CreateClusterRequest)(nil),  // 1: api.proto.v1alpha1.CreateClusterRequest
+	(*GetClusterRequest)(nil),     // 2: api.proto.v1alpha1.GetClusterRequest
+	(*ListClustersRequest)(nil),   // 3: api.proto.v1alpha1.ListClustersRequest
+	(*ListClustersResponse)(nil),  // 4: api.proto.v1alpha1.ListClustersResponse
+	(*UpdateClusterRequest)(nil),  // 5: api.proto.v1alpha1.UpdateClusterRequest
+	(*DeleteClusterRequest)(nil),  // 6: api.proto.v1alpha1.DeleteClusterRequest
+	(*fieldmaskpb.FieldMask)(nil), // 7: google.protobuf.FieldMask
+	(*LongRunningOperation)(nil),  // 8: api.proto.v1alpha1.LongRunningOperation
 }
 var file_api_proto_v1alpha1_cluster_proto_depIdxs = []int32{
 	0, // 0: api.proto.v1alpha1.CreateClusterRequest.cluster:type_name -> api.proto.v1alpha1.Cluster
 	0, // 1: api.proto.v1alpha1.ListClustersResponse.clusters:type_name -> api.proto.v1alpha1.Cluster
-	1, // 2: api.proto.v1alpha1.ClusterService.CreateCluster:input_type -> api.proto.v1alpha1.CreateClusterRequest
-	2, // 3: api.proto.v1alpha1.ClusterService.GetCluster:input_type -> api.proto.v1alpha1.GetClusterRequest
-	5, // 4: api.proto.v1alpha1.ClusterService.ListClusters:input_type -> google.protobuf.Empty
-	4, // 5: api.proto.v1alpha1.ClusterService.DeleteCluster:input_type -> api.proto.v1alpha1.DeleteClusterRequest
-	6, // 6: api.proto.v1alpha1.ClusterService.CreateCluster:output_type -> api.proto.v1alpha1.LongRunningOperation
-	0, // 7: api.proto.v1alpha1.ClusterService.GetCluster:output_type -> api.proto.v1alpha1.Cluster
-	3, // 8: api.proto.v1alpha1.ClusterService.ListClusters:output_type -> api.proto.v1alpha1.ListClustersResponse
-	6, // 9: api.proto.v1alpha1.ClusterService.DeleteCluster:output_type -> api.proto.v1alpha1.LongRunningOperation
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0, // 2: api.proto.v1alpha1.UpdateClusterRequest.cluster:type_name -> api.proto.v1alpha1.Cluster
+	7, // 3: api.proto.v1alpha1.UpdateClusterRequest.update_mask:type_name -> google.protobuf.FieldMask
+	1, // 4: api.proto.v1alpha1.ClusterService.CreateCluster:input_type -> api.proto.v1alpha1.CreateClusterRequest
+	2, // 5: api.proto.v1alpha1.ClusterService.GetCluster:input_type -> api.proto.v1alpha1.GetClusterRequest
+	3, // 6: api.proto.v1alpha1.ClusterService.ListClusters:input_type -> api.proto.v1alpha1.ListClustersRequest
+	5, // 7: api.proto.v1alpha1.ClusterService.UpdateCluster:input_type -> api.proto.v1alpha1.UpdateClusterRequest
+	6, // 8: api.proto.v1alpha1.ClusterService.DeleteCluster:input_type -> api.proto.v1alpha1.DeleteClusterRequest
+	8, // 9: api.proto.v1alpha1.ClusterService.CreateCluster:output_type -> api.proto.v1alpha1.LongRunningOperation
+	0, // 10: api.proto.v1alpha1.ClusterService.GetCluster:output_type -> api.proto.v1alpha1.Cluster
+	4, // 11: api.proto.v1alpha1.ClusterService.ListClusters:output_type -> api.proto.v1alpha1.ListClustersResponse
+	8, // 12: api.proto.v1alpha1.ClusterService.UpdateCluster:output_type -> api.proto.v1alpha1.LongRunningOperation
+	8, // 13: api.proto.v1alpha1.ClusterService.DeleteCluster:output_type -> api.proto.v1alpha1.LongRunningOperation
+	9, // [9:14] is the sub-list for method output_type
+	4, // [4:9] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1alpha1_cluster_proto_init() }
@@ -347,7 +545,7 @@ func file_api_proto_v1alpha1_cluster_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_cluster_proto_rawDesc), len(file_api_proto_v1alpha1_cluster_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,10 +11,10 @@ import (
 	sync "sync"
 	unsafe "unsafe"
 
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	anypb "google.golang.org/protobuf/types/known/anypb"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -29,7 +29,7 @@ const (
 // It is used to track the status of operations that may take a significant amount of time to complete.
 type LongRunningOperation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The unique identifier for the operation.
+	// Required. The resource name of the operation in the format `projects/{project}/operations/{operation}`.
 	// This field is read-only and is set by the system.
 	Name          string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Done          bool       `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
@@ -99,7 +99,8 @@ func (x *LongRunningOperation) GetResponse() *anypb.Any {
 
 type GetOperationRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Required. The name of the operation to retrieve.
+	// Required. The resource name of the operation to retrieve.
+	// Format: `projects/{project}/operations/{operation}`.
 	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -142,17 +143,83 @@ func (x *GetOperationRequest) GetName() string {
 	return ""
 }
 
+type ListOperationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The project to list the operations of, in the format `projects/{project}`.
+	Parent string `protobuf:"bytes,1,opt,name=parent,proto3" json:"parent,omitempty"`
+	// Optional. The maximum number of operations to return. The server may return fewer.
+	// If 0, the server chooses the number.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Optional. The next_page_token of a previous response to retrieve the next page.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOperationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOperationsRequest) ProtoMessage() {}
+
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_longrunningoperation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListOperationsRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *ListOperationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOperationsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 type ListOperationsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// A list of long-running operations.
-	Operations    []*LongRunningOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	Operations []*LongRunningOperation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// A token to retrieve the next page, or empty if there are no more operations.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListOperationsResponse) Reset() {
 	*x = ListOperationsResponse{}
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[2]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +231,7 @@ func (x *ListOperationsResponse) String() string {
 func (*ListOperationsResponse) ProtoMessage() {}
 
 func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[2]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +244,7 @@ func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
 func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_v1alpha1_longrunningoperation_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_v1alpha1_longrunningoperation_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ListOperationsResponse) GetOperations() []*LongRunningOperation {
@@ -187,18 +254,27 @@ func (x *ListOperationsResponse) GetOperations() []*LongRunningOperation {
 	return nil
 }
 
+func (x *ListOperationsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type LongRunningOperation_Pipeline struct {
-	state         protoimpl.MessageState                `protogen:"open.v1"`
-	Namespace     string                                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Spec          *LongRunningOperation_Pipeline_Spec   `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
-	Status        *LongRunningOperation_Pipeline_Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	state     protoimpl.MessageState                `protogen:"open.v1"`
+	Namespace string                                `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Spec      *LongRunningOperation_Pipeline_Spec   `protobuf:"bytes,2,opt,name=spec,proto3" json:"spec,omitempty"`
+	Status    *LongRunningOperation_Pipeline_Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// events are the most recent events of the pipeline, newest first. They are only set by GetOperation.
+	Events        []*LongRunningOperation_Pipeline_Event `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LongRunningOperation_Pipeline) Reset() {
 	*x = LongRunningOperation_Pipeline{}
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[3]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +286,7 @@ func (x *LongRunningOperation_Pipeline) String() string {
 func (*LongRunningOperation_Pipeline) ProtoMessage() {}
 
 func (x *LongRunningOperation_Pipeline) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[3]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,6 +323,13 @@ func (x *LongRunningOperation_Pipeline) GetStatus() *LongRunningOperation_Pipeli
 	return nil
 }
 
+func (x *LongRunningOperation_Pipeline) GetEvents() []*LongRunningOperation_Pipeline_Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 type LongRunningOperation_Pipeline_Spec struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -258,7 +341,7 @@ type LongRunningOperation_Pipeline_Spec struct {
 
 func (x *LongRunningOperation_Pipeline_Spec) Reset() {
 	*x = LongRunningOperation_Pipeline_Spec{}
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[4]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -270,7 +353,7 @@ func (x *LongRunningOperation_Pipeline_Spec) String() string {
 func (*LongRunningOperation_Pipeline_Spec) ProtoMessage() {}
 
 func (x *LongRunningOperation_Pipeline_Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[4]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -318,7 +401,7 @@ type LongRunningOperation_Pipeline_Status struct {
 
 func (x *LongRunningOperation_Pipeline_Status) Reset() {
 	*x = LongRunningOperation_Pipeline_Status{}
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[5]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -330,7 +413,7 @@ func (x *LongRunningOperation_Pipeline_Status) String() string {
 func (*LongRunningOperation_Pipeline_Status) ProtoMessage() {}
 
 func (x *LongRunningOperation_Pipeline_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[5]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,6 +450,84 @@ func (x *LongRunningOperation_Pipeline_Status) GetLastSynchedTime() *timestamppb
 	return nil
 }
 
+// Event is a Kubernetes event recorded on the pipeline by the controllers.
+type LongRunningOperation_Pipeline_Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type is either "Normal" or "Warning".
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	LastTimestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_timestamp,json=lastTimestamp,proto3" json:"last_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LongRunningOperation_Pipeline_Event) Reset() {
+	*x = LongRunningOperation_Pipeline_Event{}
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LongRunningOperation_Pipeline_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LongRunningOperation_Pipeline_Event) ProtoMessage() {}
+
+func (x *LongRunningOperation_Pipeline_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LongRunningOperation_Pipeline_Event.ProtoReflect.Descriptor instead.
+func (*LongRunningOperation_Pipeline_Event) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_longrunningoperation_proto_rawDescGZIP(), []int{0, 0, 2}
+}
+
+func (x *LongRunningOperation_Pipeline_Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LongRunningOperation_Pipeline_Event) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LongRunningOperation_Pipeline_Event) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *LongRunningOperation_Pipeline_Event) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *LongRunningOperation_Pipeline_Event) GetLastTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTimestamp
+	}
+	return nil
+}
+
 type LongRunningOperation_Pipeline_Status_Condition struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Message            string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -377,7 +538,7 @@ type LongRunningOperation_Pipeline_Status_Condition struct {
 
 func (x *LongRunningOperation_Pipeline_Status_Condition) Reset() {
 	*x = LongRunningOperation_Pipeline_Status_Condition{}
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[6]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -389,7 +550,7 @@ func (x *LongRunningOperation_Pipeline_Status_Condition) String() string {
 func (*LongRunningOperation_Pipeline_Status_Condition) ProtoMessage() {}
 
 func (x *LongRunningOperation_Pipeline_Status_Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[6]
+	mi := &file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -423,16 +584,17 @@ var File_api_proto_v1alpha1_longrunningoperation_proto protoreflect.FileDescript
 
 const file_api_proto_v1alpha1_longrunningoperation_proto_rawDesc = "" +
 	"\n" +
-	"-api/proto/v1alpha1/longrunningoperation.proto\x12\x12api.proto.v1alpha1\x1a\x19google/protobuf/any.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8d\x06\n" +
+	"-api/proto/v1alpha1/longrunningoperation.proto\x12\x12api.proto.v1alpha1\x1a\x1bbuf/validate/validate.proto\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x87\b\n" +
 	"\x14LongRunningOperation\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04done\x18\x02 \x01(\bR\x04done\x120\n" +
 	"\bmetadata\x18\x03 \x01(\v2\x14.google.protobuf.AnyR\bmetadata\x120\n" +
-	"\bresponse\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\bresponse\x1a\xe8\x04\n" +
+	"\bresponse\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\bresponse\x1a\xe2\x06\n" +
 	"\bPipeline\x12\x1c\n" +
 	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12J\n" +
 	"\x04spec\x18\x02 \x01(\v26.api.proto.v1alpha1.LongRunningOperation.Pipeline.SpecR\x04spec\x12P\n" +
-	"\x06status\x18\x03 \x01(\v28.api.proto.v1alpha1.LongRunningOperation.Pipeline.StatusR\x06status\x1a^\n" +
+	"\x06status\x18\x03 \x01(\v28.api.proto.v1alpha1.LongRunningOperation.Pipeline.StatusR\x06status\x12O\n" +
+	"\x06events\x18\x04 \x03(\v27.api.proto.v1alpha1.LongRunningOperation.Pipeline.EventR\x06events\x1a^\n" +
 	"\x04Spec\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdisplayName\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
@@ -445,16 +607,28 @@ const file_api_proto_v1alpha1_longrunningoperation_proto_rawDesc = "" +
 	"\x11last_synched_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastSynchedTime\x1as\n" +
 	"\tCondition\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12L\n" +
-	"\x14last_transition_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x12lastTransitionTime\")\n" +
-	"\x13GetOperationRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"b\n" +
+	"\x14last_transition_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x12lastTransitionTime\x1a\xa6\x01\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12A\n" +
+	"\x0elast_timestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastTimestamp\"\x89\x01\n" +
+	"\x13GetOperationRequest\x12r\n" +
+	"\x04name\x18\x01 \x01(\tB^\xbaH[\xc8\x01\x01rV\x18\x89\x012Q^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?/operations/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x04name\"\xaa\x01\n" +
+	"\x15ListOperationsRequest\x12L\n" +
+	"\x06parent\x18\x01 \x01(\tB4\xbaH1\xc8\x01\x01r,\x18@2(^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?$R\x06parent\x12$\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x8a\x01\n" +
 	"\x16ListOperationsResponse\x12H\n" +
 	"\n" +
 	"operations\x18\x01 \x03(\v2(.api.proto.v1alpha1.LongRunningOperationR\n" +
-	"operations2\xd6\x01\n" +
-	"\x1bLongRunningOperationService\x12a\n" +
-	"\fGetOperation\x12'.api.proto.v1alpha1.GetOperationRequest\x1a(.api.proto.v1alpha1.LongRunningOperation\x12T\n" +
-	"\x0eListOperations\x12\x16.google.protobuf.Empty\x1a*.api.proto.v1alpha1.ListOperationsResponseBMZKgithub.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1;v1alpha1b\x06proto3"
+	"operations\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xf3\x01\n" +
+	"\x1bLongRunningOperationService\x12f\n" +
+	"\fGetOperation\x12'.api.proto.v1alpha1.GetOperationRequest\x1a(.api.proto.v1alpha1.LongRunningOperation\"\x03\x90\x02\x01\x12l\n" +
+	"\x0eListOperations\x12).api.proto.v1alpha1.ListOperationsRequest\x1a*.api.proto.v1alpha1.ListOperationsResponse\"\x03\x90\x02\x01BMZKgithub.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1;v1alpha1b\x06proto3"
 
 var (
 	file_api_proto_v1alpha1_longrunningoperation_proto_rawDescOnce sync.Once
@@ -468,37 +642,40 @@ func file_api_proto_v1alpha1_longrunningoperation_proto_rawDescGZIP() []byte {
 	return file_api_proto_v1alpha1_longrunningoperation_proto_rawDescData
 }
 
-var file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_v1alpha1_longrunningoperation_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_api_proto_v1alpha1_longrunningoperation_proto_goTypes = []any{
 	(*LongRunningOperation)(nil),                           // 0: api.proto.v1alpha1.LongRunningOperation
 	(*GetOperationRequest)(nil),                            // 1: api.proto.v1alpha1.GetOperationRequest
-	(*ListOperationsResponse)(nil),                         // 2: api.proto.v1alpha1.ListOperationsResponse
-	(*LongRunningOperation_Pipeline)(nil),                  // 3: api.proto.v1alpha1.LongRunningOperation.Pipeline
-	(*LongRunningOperation_Pipeline_Spec)(nil),             // 4: api.proto.v1alpha1.LongRunningOperation.Pipeline.Spec
-	(*LongRunningOperation_Pipeline_Status)(nil),           // 5: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status
-	(*LongRunningOperation_Pipeline_Status_Condition)(nil), // 6: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition
-	(*anypb.Any)(nil),                                      // 7: google.protobuf.Any
-	(*timestamppb.Timestamp)(nil),                          // 8: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                                  // 9: google.protobuf.Empty
+	(*ListOperationsRequest)(nil),                          // 2: api.proto.v1alpha1.ListOperationsRequest
+	(*ListOperationsResponse)(nil),                         // 3: api.proto.v1alpha1.ListOperationsResponse
+	(*LongRunningOperation_Pipeline)(nil),                  // 4: api.proto.v1alpha1.LongRunningOperation.Pipeline
+	(*LongRunningOperation_Pipeline_Spec)(nil),             // 5: api.proto.v1alpha1.LongRunningOperation.Pipeline.Spec
+	(*LongRunningOperation_Pipeline_Status)(nil),           // 6: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status
+	(*LongRunningOperation_Pipeline_Event)(nil),            // 7: api.proto.v1alpha1.LongRunningOperation.Pipeline.Event
+	(*LongRunningOperation_Pipeline_Status_Condition)(nil), // 8: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition
+	(*anypb.Any)(nil),                                      // 9: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil),                          // 10: google.protobuf.Timestamp
 }
 var file_api_proto_v1alpha1_longrunningoperation_proto_depIdxs = []int32{
-	7,  // 0: api.proto.v1alpha1.LongRunningOperation.metadata:type_name -> google.protobuf.Any
-	7,  // 1: api.proto.v1alpha1.LongRunningOperation.response:type_name -> google.protobuf.Any
+	9,  // 0: api.proto.v1alpha1.LongRunningOperation.metadata:type_name -> google.protobuf.Any
+	9,  // 1: api.proto.v1alpha1.LongRunningOperation.response:type_name -> google.protobuf.Any
 	0,  // 2: api.proto.v1alpha1.ListOperationsResponse.operations:type_name -> api.proto.v1alpha1.LongRunningOperation
-	4,  // 3: api.proto.v1alpha1.LongRunningOperation.Pipeline.spec:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Spec
-	5,  // 4: api.proto.v1alpha1.LongRunningOperation.Pipeline.status:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Status
-	6,  // 5: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.conditions:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition
-	8,  // 6: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.last_synched_time:type_name -> google.protobuf.Timestamp
-	8,  // 7: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition.last_transition_time:type_name -> google.protobuf.Timestamp
-	1,  // 8: api.proto.v1alpha1.LongRunningOperationService.GetOperation:input_type -> api.proto.v1alpha1.GetOperationRequest
-	9,  // 9: api.proto.v1alpha1.LongRunningOperationService.ListOperations:input_type -> google.protobuf.Empty
-	0,  // 10: api.proto.v1alpha1.LongRunningOperationService.GetOperation:output_type -> api.proto.v1alpha1.LongRunningOperation
-	2,  // 11: api.proto.v1alpha1.LongRunningOperationService.ListOperations:output_type -> api.proto.v1alpha1.ListOperationsResponse
-	10, // [10:12] is the sub-list for method output_type
-	8,  // [8:10] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	5,  // 3: api.proto.v1alpha1.LongRunningOperation.Pipeline.spec:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Spec
+	6,  // 4: api.proto.v1alpha1.LongRunningOperation.Pipeline.status:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Status
+	7,  // 5: api.proto.v1alpha1.LongRunningOperation.Pipeline.events:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Event
+	8,  // 6: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.conditions:type_name -> api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition
+	10, // 7: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.last_synched_time:type_name -> google.protobuf.Timestamp
+	10, // 8: api.proto.v1alpha1.LongRunningOperation.Pipeline.Event.last_timestamp:type_name -> google.protobuf.Timestamp
+	10, // 9: api.proto.v1alpha1.LongRunningOperation.Pipeline.Status.Condition.last_transition_time:type_name -> google.protobuf.Timestamp
+	1,  // 10: api.proto.v1alpha1.LongRunningOperationService.GetOperation:input_type -> api.proto.v1alpha1.GetOperationRequest
+	2,  // 11: api.proto.v1alpha1.LongRunningOperationService.ListOperations:input_type -> api.proto.v1alpha1.ListOperationsRequest
+	0,  // 12: api.proto.v1alpha1.LongRunningOperationService.GetOperation:output_type -> api.proto.v1alpha1.LongRunningOperation
+	3,  // 13: api.proto.v1alpha1.LongRunningOperationService.ListOperations:output_type -> api.proto.v1alpha1.ListOperationsResponse
+	12, // [12:14] is the sub-list for method output_type
+	10, // [10:12] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_v1alpha1_longrunningoperation_proto_init() }
@@ -512,7 +689,7 @@ func file_api_proto_v1alpha1_longrunningoperation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_longrunningoperation_proto_rawDesc), len(file_api_proto_v1alpha1_longrunningoperation_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: api/proto/v1alpha1/quota.proto
+
+package v1alpha1
+
+import (
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Quota is the limits of the resources a project can use and its current usage.
+// A limit of 0 means unlimited.
+type Quota struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The resource name of the quota in the format `projects/{project}/quota`.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// max_clusters is the maximum number of clusters in the project.
+	MaxClusters int32 `protobuf:"varint,2,opt,name=max_clusters,json=maxClusters,proto3" json:"max_clusters,omitempty"`
+	// clusters is the number of clusters in the project, including the ones being created.
+	Clusters int32 `protobuf:"varint,3,opt,name=clusters,proto3" json:"clusters,omitempty"`
+	// max_pending_operations is the maximum number of operations in progress in the project.
+	MaxPendingOperations int32 `protobuf:"varint,4,opt,name=max_pending_operations,json=maxPendingOperations,proto3" json:"max_pending_operations,omitempty"`
+	// pending_operations is the number of operations in progress in the project.
+	PendingOperations int32 `protobuf:"varint,5,opt,name=pending_operations,json=pendingOperations,proto3" json:"pending_operations,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	mi := &file_api_proto_v1alpha1_quota_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_quota_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_quota_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quota) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Quota) GetMaxClusters() int32 {
+	if x != nil {
+		return x.MaxClusters
+	}
+	return 0
+}
+
+func (x *Quota) GetClusters() int32 {
+	if x != nil {
+		return x.Clusters
+	}
+	return 0
+}
+
+func (x *Quota) GetMaxPendingOperations() int32 {
+	if x != nil {
+		return x.MaxPendingOperations
+	}
+	return 0
+}
+
+func (x *Quota) GetPendingOperations() int32 {
+	if x != nil {
+		return x.PendingOperations
+	}
+	return 0
+}
+
+type GetQuotaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Required. The resource name of the quota to retrieve.
+	// Format: `projects/{project}/quota`.
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuotaRequest) Reset() {
+	*x = GetQuotaRequest{}
+	mi := &file_api_proto_v1alpha1_quota_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuotaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuotaRequest) ProtoMessage() {}
+
+func (x *GetQuotaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_v1alpha1_quota_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuotaRequest.ProtoReflect.Descriptor instead.
+func (*GetQuotaRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_v1alpha1_quota_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetQuotaRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+var File_api_proto_v1alpha1_quota_proto protoreflect.FileDescriptor
+
+const file_api_proto_v1alpha1_quota_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/proto/v1alpha1/quota.proto\x12\x12api.proto.v1alpha1\x1a\x1bbuf/validate/validate.proto\"\xbf\x01\n" +
+	"\x05Quota\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fmax_clusters\x18\x02 \x01(\x05R\vmaxClusters\x12\x1a\n" +
+	"\bclusters\x18\x03 \x01(\x05R\bclusters\x124\n" +
+	"\x16max_pending_operations\x18\x04 \x01(\x05R\x14maxPendingOperations\x12-\n" +
+	"\x12pending_operations\x18\x05 \x01(\x05R\x11pendingOperations\"a\n" +
+	"\x0fGetQuotaRequest\x12N\n" +
+	"\x04name\x18\x01 \x01(\tB:\xbaH7\xc8\x01\x01r2\x18F2.^projects/[a-z0-9]([-a-z0-9]*[a-z0-9])?/quota$R\x04name2_\n" +
+	"\fQuotaService\x12O\n" +
+	"\bGetQuota\x12#.api.proto.v1alpha1.GetQuotaRequest\x1a\x19.api.proto.v1alpha1.Quota\"\x03\x90\x02\x01BMZKgithub.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1;v1alpha1b\x06proto3"
+
+var (
+	file_api_proto_v1alpha1_quota_proto_rawDescOnce sync.Once
+	file_api_proto_v1alpha1_quota_proto_rawDescData []byte
+)
+
+func file_api_proto_v1alpha1_quota_proto_rawDescGZIP() []byte {
+	file_api_proto_v1alpha1_quota_proto_rawDescOnce.Do(func() {
+		file_api_proto_v1alpha1_quota_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_quota_proto_rawDesc), len(file_api_proto_v1alpha1_quota_proto_rawDesc)))
+	})
+	return file_api_proto_v1alpha1_quota_proto_rawDescData
+}
+
+var file_api_proto_v1alpha1_quota_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_proto_v1alpha1_quota_proto_goTypes = []any{
+	(*Quota)(nil),           // 0: api.proto.v1alpha1.Quota
+	(*GetQuotaRequest)(nil), // 1: api.proto.v1alpha1.GetQuotaRequest
+}
+var file_api_proto_v1alpha1_quota_proto_depIdxs = []int32{
+	1, // 0: api.proto.v1alpha1.QuotaService.GetQuota:input_type -> api.proto.v1alpha1.GetQuotaRequest
+	0, // 1: api.proto.v1alpha1.QuotaService.GetQuota:output_type -> api.proto.v1alpha1.Quota
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_v1alpha1_quota_proto_init() }
+func file_api_proto_v1alpha1_quota_proto_init() {
+	if File_api_proto_v1alpha1_quota_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_v1alpha1_quota_proto_rawDesc), len(file_api_proto_v1alpha1_quota_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_v1alpha1_quota_proto_goTypes,
+		DependencyIndexes: file_api_proto_v1alpha1_quota_proto_depIdxs,
+		MessageInfos:      file_api_proto_v1alpha1_quota_proto_msgTypes,
+	}.Build()
+	File_api_proto_v1alpha1_quota_proto = out.File
+	file_api_proto_v1alpha1_quota_proto_goTypes = nil
+	file_api_proto_v1alpha1_quota_proto_depIdxs = nil
+}
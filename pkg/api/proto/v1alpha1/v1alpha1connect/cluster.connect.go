@@ -12,7 +12,6 @@ import (
 
 	connect "connectrpc.com/connect"
 	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file and the connect package are
@@ -44,6 +43,9 @@ const (
 	// ClusterServiceListClustersProcedure is the fully-qualified name of the ClusterService's
 	// ListClusters RPC.
 	ClusterServiceListClustersProcedure = "/api.proto.v1alpha1.ClusterService/ListClusters"
+	// ClusterServiceUpdateClusterProcedure is the fully-qualified name of the ClusterService's
+	// UpdateCluster RPC.
+	ClusterServiceUpdateClusterProcedure = "/api.proto.v1alpha1.ClusterService/UpdateCluster"
 	// ClusterServiceDeleteClusterProcedure is the fully-qualified name of the ClusterService's
 	// DeleteCluster RPC.
 	ClusterServiceDeleteClusterProcedure = "/api.proto.v1alpha1.ClusterService/DeleteCluster"
@@ -56,8 +58,13 @@ type ClusterServiceClient interface {
 	CreateCluster(context.Context, *connect.Request[v1alpha1.CreateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
 	// GetCluster retrieves the details of a specific cluster by its name.
 	GetCluster(context.Context, *connect.Request[v1alpha1.GetClusterRequest]) (*connect.Response[v1alpha1.Cluster], error)
-	// ListClusters lists all clusters.
-	ListClusters(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListClustersResponse], error)
+	// ListClusters lists all clusters in a project.
+	ListClusters(context.Context, *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error)
+	// UpdateCluster updates the fields of a cluster selected by the update mask.
+	// It returns a LongRunningOperation that can be used to track the progress of the operation.
+	// Metadata such as display_name and description is updated immediately, and the returned operation is done
+	// with the updated Cluster as the response and without a name.
+	UpdateCluster(context.Context, *connect.Request[v1alpha1.UpdateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
 	// DeleteCluster deletes a specific cluster by its name.
 	// It returns a LongRunningOperation that can be used to track the progress of the operation.
 	DeleteCluster(context.Context, *connect.Request[v1alpha1.DeleteClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
@@ -84,12 +91,20 @@ func NewClusterServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			httpClient,
 			baseURL+ClusterServiceGetClusterProcedure,
 			connect.WithSchema(clusterServiceMethods.ByName("GetCluster")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
-		listClusters: connect.NewClient[emptypb.Empty, v1alpha1.ListClustersResponse](
+		listClusters: connect.NewClient[v1alpha1.ListClustersRequest, v1alpha1.ListClustersResponse](
 			httpClient,
 			baseURL+ClusterServiceListClustersProcedure,
 			connect.WithSchema(clusterServiceMethods.ByName("ListClusters")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		updateCluster: connect.NewClient[v1alpha1.UpdateClusterRequest, v1alpha1.LongRunningOperation](
+			httpClient,
+			baseURL+ClusterServiceUpdateClusterProcedure,
+			connect.WithSchema(clusterServiceMethods.ByName("UpdateCluster")),
 			connect.WithClientOptions(opts...),
 		),
 		deleteCluster: connect.NewClient[v1alpha1.DeleteClusterRequest, v1alpha1.LongRunningOperation](
@@ -105,7 +120,8 @@ func NewClusterServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 type clusterServiceClient struct {
 	createCluster *connect.Client[v1alpha1.CreateClusterRequest, v1alpha1.LongRunningOperation]
 	getCluster    *connect.Client[v1alpha1.GetClusterRequest, v1alpha1.Cluster]
-	listClusters  *connect.Client[emptypb.Empty, v1alpha1.ListClustersResponse]
+	listClusters  *connect.Client[v1alpha1.ListClustersRequest, v1alpha1.ListClustersResponse]
+	updateCluster *connect.Client[v1alpha1.UpdateClusterRequest, v1alpha1.LongRunningOperation]
 	deleteCluster *connect.Client[v1alpha1.DeleteClusterRequest, v1alpha1.LongRunningOperation]
 }
 
@@ -120,10 +136,15 @@ func (c *clusterServiceClient) GetCluster(ctx context.Context, req *connect.Requ
 }
 
 // ListClusters calls api.proto.v1alpha1.ClusterService.ListClusters.
-func (c *clusterServiceClient) ListClusters(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
+func (c *clusterServiceClient) ListClusters(ctx context.Context, req *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
 	return c.listClusters.CallUnary(ctx, req)
 }
 
+// UpdateCluster calls api.proto.v1alpha1.ClusterService.UpdateCluster.
+func (c *clusterServiceClient) UpdateCluster(ctx context.Context, req *connect.Request[v1alpha1.UpdateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
+	return c.updateCluster.CallUnary(ctx, req)
+}
+
 // DeleteCluster calls api.proto.v1alpha1.ClusterService.DeleteCluster.
 func (c *clusterServiceClient) DeleteCluster(ctx context.Context, req *connect.Request[v1alpha1.DeleteClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
 	return c.deleteCluster.CallUnary(ctx, req)
@@ -136,8 +157,13 @@ type ClusterServiceHandler interface {
 	CreateCluster(context.Context, *connect.Request[v1alpha1.CreateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
 	// GetCluster retrieves the details of a specific cluster by its name.
 	GetCluster(context.Context, *connect.Request[v1alpha1.GetClusterRequest]) (*connect.Response[v1alpha1.Cluster], error)
-	// ListClusters lists all clusters.
-	ListClusters(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListClustersResponse], error)
+	// ListClusters lists all clusters in a project.
+	ListClusters(context.Context, *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error)
+	// UpdateCluster updates the fields of a cluster selected by the update mask.
+	// It returns a LongRunningOperation that can be used to track the progress of the operation.
+	// Metadata such as display_name and description is updated immediately, and the returned operation is done
+	// with the updated Cluster as the response and without a name.
+	UpdateCluster(context.Context, *connect.Request[v1alpha1.UpdateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
 	// DeleteCluster deletes a specific cluster by its name.
 	// It returns a LongRunningOperation that can be used to track the progress of the operation.
 	DeleteCluster(context.Context, *connect.Request[v1alpha1.DeleteClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
@@ -160,12 +186,20 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 		ClusterServiceGetClusterProcedure,
 		svc.GetCluster,
 		connect.WithSchema(clusterServiceMethods.ByName("GetCluster")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
 	clusterServiceListClustersHandler := connect.NewUnaryHandler(
 		ClusterServiceListClustersProcedure,
 		svc.ListClusters,
 		connect.WithSchema(clusterServiceMethods.ByName("ListClusters")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	clusterServiceUpdateClusterHandler := connect.NewUnaryHandler(
+		ClusterServiceUpdateClusterProcedure,
+		svc.UpdateCluster,
+		connect.WithSchema(clusterServiceMethods.ByName("UpdateCluster")),
 		connect.WithHandlerOptions(opts...),
 	)
 	clusterServiceDeleteClusterHandler := connect.NewUnaryHandler(
@@ -182,6 +216,8 @@ func NewClusterServiceHandler(svc ClusterServiceHandler, opts ...connect.Handler
 			clusterServiceGetClusterHandler.ServeHTTP(w, r)
 		case ClusterServiceListClustersProcedure:
 			clusterServiceListClustersHandler.ServeHTTP(w, r)
+		case ClusterServiceUpdateClusterProcedure:
+			clusterServiceUpdateClusterHandler.ServeHTTP(w, r)
 		case ClusterServiceDeleteClusterProcedure:
 			clusterServiceDeleteClusterHandler.ServeHTTP(w, r)
 		default:
@@ -201,10 +237,14 @@ func (UnimplementedClusterServiceHandler) GetCluster(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.ClusterService.GetCluster is not implemented"))
 }
 
-func (UnimplementedClusterServiceHandler) ListClusters(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
+func (UnimplementedClusterServiceHandler) ListClusters(context.Context, *connect.Request[v1alpha1.ListClustersRequest]) (*connect.Response[v1alpha1.ListClustersResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.ClusterService.ListClusters is not implemented"))
 }
 
+func (UnimplementedClusterServiceHandler) UpdateCluster(context.Context, *connect.Request[v1alpha1.UpdateClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.ClusterService.UpdateCluster is not implemented"))
+}
+
 func (UnimplementedClusterServiceHandler) DeleteCluster(context.Context, *connect.Request[v1alpha1.DeleteClusterRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.ClusterService.DeleteCluster is not implemented"))
 }
@@ -12,7 +12,6 @@ import (
 
 	connect "connectrpc.com/connect"
 	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
-	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file and the connect package are
@@ -49,8 +48,9 @@ const (
 type LongRunningOperationServiceClient interface {
 	// GetOperation retrieves the details of a long-running operation by its name.
 	GetOperation(context.Context, *connect.Request[v1alpha1.GetOperationRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
-	// ListOperations lists all long-running operations.
-	ListOperations(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListOperationsResponse], error)
+	// ListOperations lists the long-running operations in a project, from the newest to the oldest.
+	// The operations do not include the events of their pipelines, which GetOperation returns.
+	ListOperations(context.Context, *connect.Request[v1alpha1.ListOperationsRequest]) (*connect.Response[v1alpha1.ListOperationsResponse], error)
 }
 
 // NewLongRunningOperationServiceClient constructs a client for the
@@ -69,12 +69,14 @@ func NewLongRunningOperationServiceClient(httpClient connect.HTTPClient, baseURL
 			httpClient,
 			baseURL+LongRunningOperationServiceGetOperationProcedure,
 			connect.WithSchema(longRunningOperationServiceMethods.ByName("GetOperation")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
-		listOperations: connect.NewClient[emptypb.Empty, v1alpha1.ListOperationsResponse](
+		listOperations: connect.NewClient[v1alpha1.ListOperationsRequest, v1alpha1.ListOperationsResponse](
 			httpClient,
 			baseURL+LongRunningOperationServiceListOperationsProcedure,
 			connect.WithSchema(longRunningOperationServiceMethods.ByName("ListOperations")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
 	}
@@ -83,7 +85,7 @@ func NewLongRunningOperationServiceClient(httpClient connect.HTTPClient, baseURL
 // longRunningOperationServiceClient implements LongRunningOperationServiceClient.
 type longRunningOperationServiceClient struct {
 	getOperation   *connect.Client[v1alpha1.GetOperationRequest, v1alpha1.LongRunningOperation]
-	listOperations *connect.Client[emptypb.Empty, v1alpha1.ListOperationsResponse]
+	listOperations *connect.Client[v1alpha1.ListOperationsRequest, v1alpha1.ListOperationsResponse]
 }
 
 // GetOperation calls api.proto.v1alpha1.LongRunningOperationService.GetOperation.
@@ -92,7 +94,7 @@ func (c *longRunningOperationServiceClient) GetOperation(ctx context.Context, re
 }
 
 // ListOperations calls api.proto.v1alpha1.LongRunningOperationService.ListOperations.
-func (c *longRunningOperationServiceClient) ListOperations(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListOperationsResponse], error) {
+func (c *longRunningOperationServiceClient) ListOperations(ctx context.Context, req *connect.Request[v1alpha1.ListOperationsRequest]) (*connect.Response[v1alpha1.ListOperationsResponse], error) {
 	return c.listOperations.CallUnary(ctx, req)
 }
 
@@ -101,8 +103,9 @@ func (c *longRunningOperationServiceClient) ListOperations(ctx context.Context,
 type LongRunningOperationServiceHandler interface {
 	// GetOperation retrieves the details of a long-running operation by its name.
 	GetOperation(context.Context, *connect.Request[v1alpha1.GetOperationRequest]) (*connect.Response[v1alpha1.LongRunningOperation], error)
-	// ListOperations lists all long-running operations.
-	ListOperations(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListOperationsResponse], error)
+	// ListOperations lists the long-running operations in a project, from the newest to the oldest.
+	// The operations do not include the events of their pipelines, which GetOperation returns.
+	ListOperations(context.Context, *connect.Request[v1alpha1.ListOperationsRequest]) (*connect.Response[v1alpha1.ListOperationsResponse], error)
 }
 
 // NewLongRunningOperationServiceHandler builds an HTTP handler from the service implementation. It
@@ -116,12 +119,14 @@ func NewLongRunningOperationServiceHandler(svc LongRunningOperationServiceHandle
 		LongRunningOperationServiceGetOperationProcedure,
 		svc.GetOperation,
 		connect.WithSchema(longRunningOperationServiceMethods.ByName("GetOperation")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
 	longRunningOperationServiceListOperationsHandler := connect.NewUnaryHandler(
 		LongRunningOperationServiceListOperationsProcedure,
 		svc.ListOperations,
 		connect.WithSchema(longRunningOperationServiceMethods.ByName("ListOperations")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
 	return "/api.proto.v1alpha1.LongRunningOperationService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +148,6 @@ func (UnimplementedLongRunningOperationServiceHandler) GetOperation(context.Cont
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.LongRunningOperationService.GetOperation is not implemented"))
 }
 
-func (UnimplementedLongRunningOperationServiceHandler) ListOperations(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListOperationsResponse], error) {
+func (UnimplementedLongRunningOperationServiceHandler) ListOperations(context.Context, *connect.Request[v1alpha1.ListOperationsRequest]) (*connect.Response[v1alpha1.ListOperationsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.LongRunningOperationService.ListOperations is not implemented"))
 }
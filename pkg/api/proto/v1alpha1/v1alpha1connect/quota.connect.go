@@ -0,0 +1,113 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/proto/v1alpha1/quota.proto
+
+package v1alpha1connect
+
+import (
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+
+	connect "connectrpc.com/connect"
+	v1alpha1 "github.com/nokamoto/kaas-operator-prototype/pkg/api/proto/v1alpha1"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// QuotaServiceName is the fully-qualified name of the QuotaService service.
+	QuotaServiceName = "api.proto.v1alpha1.QuotaService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// QuotaServiceGetQuotaProcedure is the fully-qualified name of the QuotaService's GetQuota RPC.
+	QuotaServiceGetQuotaProcedure = "/api.proto.v1alpha1.QuotaService/GetQuota"
+)
+
+// QuotaServiceClient is a client for the api.proto.v1alpha1.QuotaService service.
+type QuotaServiceClient interface {
+	// GetQuota retrieves the quota limits of a project and its current usage.
+	GetQuota(context.Context, *connect.Request[v1alpha1.GetQuotaRequest]) (*connect.Response[v1alpha1.Quota], error)
+}
+
+// NewQuotaServiceClient constructs a client for the api.proto.v1alpha1.QuotaService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewQuotaServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) QuotaServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	quotaServiceMethods := v1alpha1.File_api_proto_v1alpha1_quota_proto.Services().ByName("QuotaService").Methods()
+	return &quotaServiceClient{
+		getQuota: connect.NewClient[v1alpha1.GetQuotaRequest, v1alpha1.Quota](
+			httpClient,
+			baseURL+QuotaServiceGetQuotaProcedure,
+			connect.WithSchema(quotaServiceMethods.ByName("GetQuota")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// quotaServiceClient implements QuotaServiceClient.
+type quotaServiceClient struct {
+	getQuota *connect.Client[v1alpha1.GetQuotaRequest, v1alpha1.Quota]
+}
+
+// GetQuota calls api.proto.v1alpha1.QuotaService.GetQuota.
+func (c *quotaServiceClient) GetQuota(ctx context.Context, req *connect.Request[v1alpha1.GetQuotaRequest]) (*connect.Response[v1alpha1.Quota], error) {
+	return c.getQuota.CallUnary(ctx, req)
+}
+
+// QuotaServiceHandler is an implementation of the api.proto.v1alpha1.QuotaService service.
+type QuotaServiceHandler interface {
+	// GetQuota retrieves the quota limits of a project and its current usage.
+	GetQuota(context.Context, *connect.Request[v1alpha1.GetQuotaRequest]) (*connect.Response[v1alpha1.Quota], error)
+}
+
+// NewQuotaServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewQuotaServiceHandler(svc QuotaServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	quotaServiceMethods := v1alpha1.File_api_proto_v1alpha1_quota_proto.Services().ByName("QuotaService").Methods()
+	quotaServiceGetQuotaHandler := connect.NewUnaryHandler(
+		QuotaServiceGetQuotaProcedure,
+		svc.GetQuota,
+		connect.WithSchema(quotaServiceMethods.ByName("GetQuota")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.proto.v1alpha1.QuotaService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case QuotaServiceGetQuotaProcedure:
+			quotaServiceGetQuotaHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedQuotaServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedQuotaServiceHandler struct{}
+
+func (UnimplementedQuotaServiceHandler) GetQuota(context.Context, *connect.Request[v1alpha1.GetQuotaRequest]) (*connect.Response[v1alpha1.Quota], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.proto.v1alpha1.QuotaService.GetQuota is not implemented"))
+}